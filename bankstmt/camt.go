@@ -0,0 +1,127 @@
+package bankstmt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Ref       string     `xml:"NtryRef"`
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Status    string     `xml:"Sts"`
+	BookDate  camtDate   `xml:"BookgDt"`
+	ValueDate camtDate   `xml:"ValDt"`
+	ServRef   string     `xml:"AcctSvcrRef"`
+	Details   []struct {
+		EndToEndID string   `xml:"Refs>EndToEndId"`
+		InstrID    string   `xml:"Refs>InstrId"`
+		Debtor     string   `xml:"RltdPties>Dbtr>Nm"`
+		DebtorIBAN string   `xml:"RltdPties>DbtrAcct>Id>IBAN"`
+		DebtorAcct string   `xml:"RltdPties>DbtrAcct>Id>Othr>Id"`
+		Creditor   string   `xml:"RltdPties>Cdtr>Nm"`
+		Unstruct   []string `xml:"RmtInf>Ustrd"`
+		CredRef    string   `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	} `xml:"NtryDtls>TxDtls"`
+	Info string `xml:"AddtlNtryInf"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) day() string {
+	if d.Date != "" {
+		return d.Date
+	}
+	if len(d.DateTime) >= 10 {
+		return d.DateTime[:10]
+	}
+	return ""
+}
+
+// ParseCAMT parses an ISO 20022 CAMT.053 bank-to-customer statement. Entries that are
+// not booked are skipped.
+func ParseCAMT(data []byte) ([]Transaction, error) {
+	var doc camtDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse CAMT.053: %w", err)
+	}
+
+	var result []Transaction
+	for _, stmt := range doc.Statements {
+		for _, e := range stmt.Entries {
+			if e.Status != "" && !strings.EqualFold(e.Status, "BOOK") {
+				continue
+			}
+			amount, err := fakturoid.ParseAmount(strings.TrimSpace(e.Amount.Value))
+			if err != nil {
+				return nil, fmt.Errorf("entry %s: amount: %w", e.Ref, err)
+			}
+			if e.Indicator == "DBIT" {
+				amount = amount.Neg()
+			}
+			t := Transaction{
+				ID:       firstNonEmpty(e.ServRef, e.Ref),
+				Date:     firstNonEmpty(e.ValueDate.day(), e.BookDate.day()),
+				Amount:   amount,
+				Currency: e.Amount.Currency,
+				Message:  e.Info,
+			}
+			if len(e.Details) > 0 {
+				d := e.Details[0]
+				t.CounterpartyName = d.Debtor
+				if amount.Sign() < 0 {
+					t.CounterpartyName = d.Creditor
+				}
+				t.CounterpartyAccount = firstNonEmpty(d.DebtorIBAN, d.DebtorAcct)
+				if len(d.Unstruct) > 0 {
+					t.Message = strings.Join(d.Unstruct, " ")
+				}
+				t.VariableSymbol = findVariableSymbol(d.EndToEndID, d.InstrID, d.CredRef, t.Message)
+				if t.VariableSymbol == "" && isDigits(d.EndToEndID) && len(d.EndToEndID) <= 10 {
+					t.VariableSymbol = d.EndToEndID
+				}
+			}
+			t.VariableSymbol = NormalizeSymbol(t.VariableSymbol)
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
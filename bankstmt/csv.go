@@ -0,0 +1,172 @@
+package bankstmt
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// csvHeaders lists header names (lowercase, without diacritics) used by Czech banks'
+// CSV exports (Fio, ČSOB, KB, Air Bank, Raiffeisenbank, Moneta, …) for each field.
+var csvHeaders = map[string][]string{
+	"date":     {"datum", "datum zauctovani", "datum provedeni", "datum transakce", "datum pohybu", "zauctovano", "date", "booking date", "value date"},
+	"amount":   {"objem", "castka", "castka v mene uctu", "zauctovana castka", "objem transakce", "amount"},
+	"currency": {"mena", "mena uctu", "currency"},
+	"vs":       {"vs", "variabilni symbol", "variable symbol"},
+	"ks":       {"ks", "konstantni symbol", "constant symbol"},
+	"ss":       {"ss", "specificky symbol", "specific symbol"},
+	"account":  {"protiucet", "cislo protiuctu", "ucet protistrany", "cislo uctu protistrany", "protiucet a kod banky", "counter account", "counterparty account"},
+	"bank":     {"kod banky", "kod banky protiuctu", "bank code"},
+	"name":     {"nazev protiuctu", "nazev uctu protistrany", "nazev protistrany", "protistrana", "jmeno protistrany", "nazev uctu", "counterparty name", "counterparty"},
+	"message":  {"zprava pro prijemce", "zprava", "informace pro prijemce", "poznamka", "popis", "popis transakce", "message", "detail"},
+	"id":       {"id pohybu", "id transakce", "transaction id", "id"},
+}
+
+var csvDateLayouts = []string{"02.01.2006", "2.1.2006", "2006-01-02", "02/01/2006", "2.1.2006 15:04", "02.01.2006 15:04:05"}
+
+// ParseCSV parses a bank's CSV export. The header row is located by its column names,
+// so preamble lines (account info, balances) are skipped.
+func ParseCSV(data []byte) ([]Transaction, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		data = decodeWindows1250(data)
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var columns map[string]int
+	var result []Transaction
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if columns == nil {
+			columns = headerColumns(record)
+			continue
+		}
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if get("amount") == "" {
+			continue
+		}
+
+		amount, err := parseCSVAmount(get("amount"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		t := Transaction{
+			ID:               get("id"),
+			Amount:           amount,
+			Currency:         strings.ToUpper(get("currency")),
+			VariableSymbol:   NormalizeSymbol(get("vs")),
+			ConstantSymbol:   NormalizeSymbol(get("ks")),
+			SpecificSymbol:   NormalizeSymbol(get("ss")),
+			CounterpartyName: get("name"),
+			Message:          get("message"),
+		}
+		t.CounterpartyAccount = get("account")
+		if bank := get("bank"); bank != "" && t.CounterpartyAccount != "" && !strings.Contains(t.CounterpartyAccount, "/") {
+			t.CounterpartyAccount += "/" + bank
+		}
+		if t.VariableSymbol == "" {
+			t.VariableSymbol = NormalizeSymbol(findVariableSymbol(t.Message))
+		}
+		date := get("date")
+		for _, layout := range csvDateLayouts {
+			if d, err := time.Parse(layout, date); err == nil {
+				date = d.Format("2006-01-02")
+				break
+			}
+		}
+		t.Date = date
+		result = append(result, t)
+	}
+	if columns == nil {
+		return nil, fmt.Errorf("no header row with date and amount columns found")
+	}
+	return result, nil
+}
+
+// headerColumns maps fields to column indexes. It returns nil unless the record has at
+// least a date and an amount column.
+func headerColumns(record []string) map[string]int {
+	columns := map[string]int{}
+	for i, h := range record {
+		name := normalizeHeader(h)
+		for field, names := range csvHeaders {
+			if _, seen := columns[field]; seen {
+				continue
+			}
+			for _, n := range names {
+				if name == n {
+					columns[field] = i
+				}
+			}
+		}
+	}
+	_, hasDate := columns["date"]
+	_, hasAmount := columns["amount"]
+	if !hasDate || !hasAmount {
+		return nil
+	}
+	return columns
+}
+
+var diacritics = strings.NewReplacer(
+	"á", "a", "č", "c", "ď", "d", "é", "e", "ě", "e", "í", "i", "ň", "n", "ó", "o",
+	"ř", "r", "š", "s", "ť", "t", "ú", "u", "ů", "u", "ý", "y", "ž", "z",
+)
+
+func normalizeHeader(h string) string {
+	h = strings.ToLower(strings.Trim(strings.TrimSpace(h), `"`))
+	return strings.Join(strings.Fields(diacritics.Replace(h)), " ")
+}
+
+// detectDelimiter picks the most frequent of ';', ',' and tab in the first lines.
+func detectDelimiter(data []byte) rune {
+	sample := data
+	if len(sample) > 4096 {
+		sample = sample[:4096]
+	}
+	best, count := ';', -1
+	for _, d := range []rune{';', '\t', ','} {
+		if n := bytes.Count(sample, []byte(string(d))); n > count {
+			best, count = d, n
+		}
+	}
+	return best
+}
+
+// parseCSVAmount accepts "1 234,50", "-1234.50", "1.234,50" and "1,234.50".
+func parseCSVAmount(s string) (fakturoid.Amount, error) {
+	s = strings.NewReplacer(" ", "", " ", "", "+", "").Replace(s)
+	// Whichever separator comes last is the decimal one.
+	if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+		s = strings.ReplaceAll(s, ".", "")
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	a, err := fakturoid.ParseAmount(s)
+	if err != nil {
+		return fakturoid.Amount{}, fmt.Errorf("amount %q: %w", s, err)
+	}
+	return a, nil
+}
@@ -0,0 +1,80 @@
+package bankstmt
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// gpcCurrencies maps ISO 4217 numeric codes used in GPC files.
+var gpcCurrencies = map[string]string{
+	"203": "CZK",
+	"978": "EUR",
+	"840": "USD",
+	"826": "GBP",
+	"348": "HUF",
+	"985": "PLN",
+	"756": "CHF",
+}
+
+// ParseGPC parses the ABO/GPC fixed-width format used by Czech banks. Only transaction
+// records (075) are read; files in Windows-1250 are converted to UTF-8.
+func ParseGPC(data []byte) ([]Transaction, error) {
+	if !utf8.Valid(data) {
+		data = decodeWindows1250(data)
+	}
+	var result []Transaction
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := []rune(strings.TrimRight(sc.Text(), "\r"))
+		if len(line) < 3 || string(line[:3]) != "075" {
+			continue
+		}
+		if len(line) < 122 {
+			return nil, fmt.Errorf("line %d: transaction record is too short", n)
+		}
+		field := func(from, to int) string { return strings.TrimSpace(string(line[from-1 : to])) }
+
+		halere, err := fakturoid.ParseAmount(field(49, 60))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: amount: %w", n, err)
+		}
+		amount, _ := halere.Div(fakturoid.NewAmount(100))
+		// Accounting codes: 1 debit, 2 credit, 4 reversed debit, 5 reversed credit.
+		switch field(61, 61) {
+		case "1", "5":
+			amount = amount.Neg()
+		}
+
+		t := Transaction{
+			ID:             field(36, 48),
+			Amount:         amount,
+			VariableSymbol: NormalizeSymbol(field(62, 71)),
+			ConstantSymbol: NormalizeSymbol(field(78, 81)),
+			SpecificSymbol: NormalizeSymbol(field(82, 91)),
+			Currency:       gpcCurrencies[strings.TrimLeft(field(119, 122), "0")],
+		}
+		if t.Currency == "" {
+			t.Currency = "CZK"
+		}
+		if account := strings.TrimLeft(field(20, 35), "0"); account != "" {
+			t.CounterpartyAccount = account + "/" + field(74, 77)
+		}
+		if len(line) >= 117 {
+			t.CounterpartyName = field(98, 117)
+		}
+		if d, err := time.Parse("020106", field(92, 97)); err == nil {
+			t.Date = d.Format("2006-01-02")
+		}
+		result = append(result, t)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
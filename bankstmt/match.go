@@ -0,0 +1,198 @@
+package bankstmt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Match statuses.
+const (
+	StatusMatched   = "matched"
+	StatusPartial   = "partial"
+	StatusAmbiguous = "ambiguous"
+	StatusUnmatched = "unmatched"
+)
+
+// InvoiceRef identifies an open invoice a transaction may pay.
+type InvoiceRef struct {
+	ID             int              `json:"id"`
+	Number         string           `json:"number"`
+	Subject        string           `json:"subject"`
+	VariableSymbol string           `json:"variable_symbol,omitempty"`
+	Remaining      fakturoid.Amount `json:"remaining"`
+	Currency       string           `json:"currency"`
+}
+
+type Match struct {
+	Transaction Transaction  `json:"transaction"`
+	Status      string       `json:"status"`
+	Invoice     *InvoiceRef  `json:"invoice,omitempty"`
+	Candidates  []InvoiceRef `json:"candidates,omitempty"`
+	Reason      string       `json:"reason"`
+}
+
+type MatchResult struct {
+	Matched   []Match `json:"matched"`
+	Partial   []Match `json:"partial"`
+	Ambiguous []Match `json:"ambiguous"`
+	Unmatched []Match `json:"unmatched"`
+	// Outgoing counts debit transactions, which are ignored.
+	Outgoing int `json:"outgoing"`
+}
+
+// MatchPayments pairs incoming transactions with open invoices. A variable symbol match
+// with the exact remaining amount is a match; a lower amount is a partial payment. Without
+// a variable symbol, a unique invoice with the same amount and counterparty name matches.
+// An invoice may be paid in parts by several transactions; each is compared with what the
+// earlier ones left to pay.
+func MatchPayments(transactions []Transaction, invoices []fakturoid.Invoice) MatchResult {
+	result := MatchResult{
+		Matched:   []Match{},
+		Partial:   []Match{},
+		Ambiguous: []Match{},
+		Unmatched: []Match{},
+	}
+	// assigned sums the transactions matched to each invoice so far.
+	assigned := map[int]fakturoid.Amount{}
+
+	for _, t := range transactions {
+		if !t.Incoming() {
+			result.Outgoing++
+			continue
+		}
+		m := matchOne(t, invoices, assigned)
+		if m.Invoice != nil {
+			assigned[m.Invoice.ID] = assigned[m.Invoice.ID].Add(t.Amount)
+		}
+
+		switch m.Status {
+		case StatusMatched:
+			result.Matched = append(result.Matched, m)
+		case StatusPartial:
+			result.Partial = append(result.Partial, m)
+		case StatusAmbiguous:
+			result.Ambiguous = append(result.Ambiguous, m)
+		default:
+			result.Unmatched = append(result.Unmatched, m)
+		}
+	}
+	return result
+}
+
+func matchOne(t Transaction, invoices []fakturoid.Invoice, assigned map[int]fakturoid.Amount) Match {
+	m := Match{Transaction: t, Status: StatusUnmatched}
+
+	// ref describes an invoice with the amount left after earlier transactions.
+	ref := func(inv fakturoid.Invoice) InvoiceRef {
+		r := invoiceRef(inv)
+		r.Remaining = r.Remaining.Sub(assigned[inv.ID])
+		return r
+	}
+	refs := func(list []fakturoid.Invoice) []InvoiceRef {
+		result := make([]InvoiceRef, len(list))
+		for i, inv := range list {
+			result[i] = ref(inv)
+		}
+		return result
+	}
+
+	var bySymbol, byAmount []fakturoid.Invoice
+	for _, inv := range invoices {
+		if t.Currency != "" && !strings.EqualFold(t.Currency, inv.Currency) {
+			continue
+		}
+		if t.VariableSymbol != "" && NormalizeSymbol(inv.VariableSymbol) == t.VariableSymbol {
+			bySymbol = append(bySymbol, inv)
+		}
+		if ref(inv).Remaining.Cmp(t.Amount) == 0 {
+			byAmount = append(byAmount, inv)
+		}
+	}
+
+	switch {
+	case len(bySymbol) == 1:
+		inv := ref(bySymbol[0])
+		if inv.Remaining.Sign() <= 0 {
+			m.Status, m.Reason = StatusAmbiguous, "variable symbol; invoice already paid by earlier transactions"
+			m.Candidates = []InvoiceRef{inv}
+			return m
+		}
+		m.Invoice = &inv
+		switch c := t.Amount.Cmp(inv.Remaining); {
+		case c == 0:
+			m.Status, m.Reason = StatusMatched, "variable symbol and amount"
+		case c < 0:
+			m.Status, m.Reason = StatusPartial, fmt.Sprintf("variable symbol; pays %s of %s", t.Amount, inv.Remaining)
+		default:
+			m.Status, m.Reason = StatusAmbiguous, fmt.Sprintf("variable symbol; overpaid by %s", t.Amount.Sub(inv.Remaining))
+			m.Candidates, m.Invoice = []InvoiceRef{inv}, nil
+		}
+		return m
+
+	case len(bySymbol) > 1:
+		m.Status, m.Reason = StatusAmbiguous, "several invoices share the variable symbol"
+		m.Candidates = refs(bySymbol)
+		return m
+	}
+
+	if len(byAmount) == 0 {
+		m.Reason = "no open invoice with this variable symbol or amount"
+		return m
+	}
+	var byName []fakturoid.Invoice
+	for _, inv := range byAmount {
+		if sameName(t.CounterpartyName, firstNonEmpty(inv.ClientName, inv.SubjectName)) {
+			byName = append(byName, inv)
+		}
+	}
+	if len(byName) == 1 {
+		inv := ref(byName[0])
+		m.Invoice = &inv
+		m.Status, m.Reason = StatusMatched, "amount and counterparty name"
+		return m
+	}
+	m.Status = StatusAmbiguous
+	m.Reason = "amount only"
+	m.Candidates = refs(byAmount)
+	if len(byName) > 1 {
+		m.Reason = "amount and counterparty name match several invoices"
+		m.Candidates = refs(byName)
+	}
+	return m
+}
+
+func remaining(inv fakturoid.Invoice) fakturoid.Amount {
+	if inv.RemainingAmount.IsZero() {
+		return inv.Total
+	}
+	return inv.RemainingAmount
+}
+
+func invoiceRef(inv fakturoid.Invoice) InvoiceRef {
+	return InvoiceRef{
+		ID:             inv.ID,
+		Number:         inv.Number,
+		Subject:        firstNonEmpty(inv.ClientName, inv.SubjectName),
+		VariableSymbol: inv.VariableSymbol,
+		Remaining:      remaining(inv),
+		Currency:       inv.Currency,
+	}
+}
+
+var legalForms = strings.NewReplacer("s.r.o.", "", "spol. s r.o.", "", "a.s.", "", "v.o.s.", "", "k.s.", "", "z.s.", "", ",", "", ".", "")
+
+// sameName compares counterparty names loosely: case, diacritics and legal form are
+// ignored and one name may contain the other.
+func sameName(a, b string) bool {
+	norm := func(s string) string {
+		s = diacritics.Replace(strings.ToLower(s))
+		return strings.Join(strings.Fields(legalForms.Replace(s)), " ")
+	}
+	a, b = norm(a), norm(b)
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
@@ -0,0 +1,116 @@
+package bankstmt
+
+import (
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func openInvoice(id int, number, vs, client, remaining string) fakturoid.Invoice {
+	return fakturoid.Invoice{
+		ID:              id,
+		Number:          number,
+		VariableSymbol:  vs,
+		ClientName:      client,
+		Currency:        "CZK",
+		Total:           fakturoid.MustParseAmount(remaining),
+		RemainingAmount: fakturoid.MustParseAmount(remaining),
+	}
+}
+
+func payment(id, vs, name, amount string) Transaction {
+	return Transaction{ID: id, Date: "2026-03-10", Amount: fakturoid.MustParseAmount(amount), Currency: "CZK", VariableSymbol: vs, CounterpartyName: name}
+}
+
+func TestMatchPayments(t *testing.T) {
+	invoices := []fakturoid.Invoice{
+		openInvoice(1, "2026-0001", "20260001", "Alfa s.r.o.", "1000"),
+		openInvoice(2, "2026-0002", "20260002", "Beta a.s.", "2500"),
+		openInvoice(3, "2026-0003", "20260003", "Gama", "700"),
+		openInvoice(4, "2026-0004", "20260004", "Delta", "700"),
+		openInvoice(5, "2026-0005", "20260005", "Epsilon", "300"),
+	}
+	transactions := []Transaction{
+		payment("t1", "20260001", "", "1000"),
+		payment("t2", "20260002", "", "1000"),
+		payment("t3", "", "GAMA", "700"),
+		payment("t4", "", "Unknown", "700"),
+		payment("t5", "20260005", "", "400"),
+		payment("t6", "99999", "", "123"),
+		payment("t7", "", "", "-50"),
+	}
+	res := MatchPayments(transactions, invoices)
+
+	status := map[string]string{}
+	for _, group := range [][]Match{res.Matched, res.Partial, res.Ambiguous, res.Unmatched} {
+		for _, m := range group {
+			status[m.Transaction.ID] = m.Status
+		}
+	}
+	want := map[string]string{
+		"t1": StatusMatched,   // variable symbol and amount
+		"t2": StatusPartial,   // variable symbol, lower amount
+		"t3": StatusMatched,   // amount and counterparty name
+		"t4": StatusAmbiguous, // amount only, two invoices
+		"t5": StatusAmbiguous, // overpaid
+		"t6": StatusUnmatched,
+	}
+	for id, s := range want {
+		if status[id] != s {
+			t.Errorf("%s: status %q, want %q", id, status[id], s)
+		}
+	}
+	if res.Outgoing != 1 {
+		t.Errorf("outgoing = %d, want 1", res.Outgoing)
+	}
+	if m := res.Matched[1]; m.Invoice == nil || m.Invoice.ID != 3 {
+		t.Errorf("t3 matched %+v, want invoice 3", m.Invoice)
+	}
+}
+
+func TestMatchPaymentsSplit(t *testing.T) {
+	invoices := []fakturoid.Invoice{openInvoice(1, "2026-0001", "0020260001", "Alfa", "1000")}
+	transactions := []Transaction{
+		payment("t1", "20260001", "", "400"),
+		payment("t2", "20260001", "", "600"),
+		payment("t3", "20260001", "", "100"),
+	}
+	res := MatchPayments(transactions, invoices)
+
+	if len(res.Partial) != 1 || res.Partial[0].Transaction.ID != "t1" {
+		t.Fatalf("partial = %+v, want t1", res.Partial)
+	}
+	// The second part pays what the first one left.
+	if len(res.Matched) != 1 || res.Matched[0].Transaction.ID != "t2" || res.Matched[0].Invoice.Remaining.String() != "600" {
+		t.Fatalf("matched = %+v, want t2 paying the remaining 600", res.Matched)
+	}
+	if len(res.Ambiguous) != 1 || res.Ambiguous[0].Transaction.ID != "t3" {
+		t.Errorf("ambiguous = %+v, want t3 for the already paid invoice", res.Ambiguous)
+	}
+}
+
+func TestMatchPaymentsByAmountOnce(t *testing.T) {
+	invoices := []fakturoid.Invoice{openInvoice(1, "2026-0001", "", "Alfa", "500")}
+	res := MatchPayments([]Transaction{payment("t1", "", "Alfa", "500"), payment("t2", "", "Alfa", "500")}, invoices)
+	if len(res.Matched) != 1 || len(res.Unmatched) != 1 || res.Unmatched[0].Transaction.ID != "t2" {
+		t.Errorf("matched %d, unmatched %+v; want t2 unmatched once the invoice is paid", len(res.Matched), res.Unmatched)
+	}
+}
+
+func TestSameName(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"ALFA S.R.O.", "Alfa s.r.o.", true},
+		{"Příliš Žluťoučký", "prilis zlutoucky", true},
+		{"Novák Jan", "Jan Novák, IČO 123", false},
+		{"Alfa", "Alfa Trading a.s.", true},
+		{"", "Alfa", false},
+	}
+	for _, tt := range tests {
+		if got := sameName(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameName(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
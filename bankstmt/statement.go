@@ -0,0 +1,84 @@
+// Package bankstmt parses bank statement exports (ABO/GPC, CAMT.053, CSV) and matches
+// incoming payments to open invoices.
+package bankstmt
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Statement formats.
+const (
+	FormatGPC  = "gpc"
+	FormatCAMT = "camt"
+	FormatCSV  = "csv"
+)
+
+// Transaction is one statement entry. Amount is positive for incoming payments.
+type Transaction struct {
+	ID                  string           `json:"id,omitempty"`
+	Date                string           `json:"date"`
+	Amount              fakturoid.Amount `json:"amount"`
+	Currency            string           `json:"currency"`
+	VariableSymbol      string           `json:"variable_symbol,omitempty"`
+	ConstantSymbol      string           `json:"constant_symbol,omitempty"`
+	SpecificSymbol      string           `json:"specific_symbol,omitempty"`
+	CounterpartyAccount string           `json:"counterparty_account,omitempty"`
+	CounterpartyName    string           `json:"counterparty_name,omitempty"`
+	Message             string           `json:"message,omitempty"`
+}
+
+// Incoming reports whether the transaction credits the account.
+func (t Transaction) Incoming() bool {
+	return t.Amount.Sign() > 0
+}
+
+// Parse detects the statement format and parses it. format may be empty for detection.
+func Parse(data []byte, format string) ([]Transaction, error) {
+	if format == "" {
+		format = DetectFormat(data)
+	}
+	switch format {
+	case FormatGPC:
+		return ParseGPC(data)
+	case FormatCAMT:
+		return ParseCAMT(data)
+	case FormatCSV:
+		return ParseCSV(data)
+	}
+	return nil, fmt.Errorf("unknown statement format %q", format)
+}
+
+// DetectFormat guesses the statement format from its content.
+func DetectFormat(data []byte) string {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \r\n\t")
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("074")):
+		return FormatGPC
+	case bytes.HasPrefix(trimmed, []byte("<")) && bytes.Contains(data, []byte("BkToCstmrStmt")):
+		return FormatCAMT
+	}
+	return FormatCSV
+}
+
+var vsPattern = regexp.MustCompile(`(?i)\bVS[:/ ]?\s*(\d{1,10})\b`)
+
+// findVariableSymbol extracts a variable symbol from free text such as "/VS123/SS/KS0308"
+// or "VS: 2024001".
+func findVariableSymbol(texts ...string) string {
+	for _, t := range texts {
+		if m := vsPattern.FindStringSubmatch(t); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// NormalizeSymbol strips leading zeros so "0002024001" and "2024001" compare equal.
+func NormalizeSymbol(s string) string {
+	return strings.TrimLeft(strings.TrimSpace(s), "0")
+}
@@ -0,0 +1,111 @@
+package bankstmt
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func TestParse(t *testing.T) {
+	amount := fakturoid.MustParseAmount
+	tests := []struct {
+		file   string
+		format string
+		want   []Transaction
+	}{
+		{"statement.gpc", FormatGPC, []Transaction{
+			{ID: "0000000001001", Date: "2026-03-10", Amount: amount("1000"), Currency: "CZK", VariableSymbol: "20260001", ConstantSymbol: "308",
+				CounterpartyAccount: "2701234567/0800", CounterpartyName: "Žluťoučký kůň"},
+			{ID: "0000000001002", Date: "2026-03-11", Amount: amount("250.5"), Currency: "EUR",
+				CounterpartyAccount: "19-123457/0100", CounterpartyName: "Beta a.s."},
+			{ID: "0000000001003", Date: "2026-03-12", Amount: amount("-50"), Currency: "CZK", CounterpartyName: "Poplatek"},
+		}},
+		{"statement.xml", FormatCAMT, []Transaction{
+			{ID: "2001", Date: "2026-03-10", Amount: amount("1000"), Currency: "CZK", VariableSymbol: "20260001",
+				CounterpartyAccount: "CZ6508000000192000145399", CounterpartyName: "Alfa s.r.o.", Message: "Faktura 2026-0001"},
+			{ID: "2002", Date: "2026-03-11", Amount: amount("250.5"), Currency: "CZK", VariableSymbol: "20260002", CounterpartyName: "Beta a.s."},
+			{ID: "2003", Date: "2026-03-12", Amount: amount("-50"), Currency: "CZK", CounterpartyName: "Banka", Message: "Poplatek za vedení účtu"},
+		}},
+		{"statement_fio.csv", FormatCSV, []Transaction{
+			{ID: "26001", Date: "2026-03-10", Amount: amount("1000"), Currency: "CZK", VariableSymbol: "20260001", ConstantSymbol: "308",
+				CounterpartyAccount: "2701234567/2010", CounterpartyName: "Alfa s.r.o.", Message: "Faktura"},
+			{ID: "26002", Date: "2026-03-11", Amount: amount("250.5"), Currency: "CZK", VariableSymbol: "20260002",
+				CounterpartyAccount: "19-123457/0100", CounterpartyName: "Beta a.s.", Message: "VS: 0020260002"},
+			{ID: "26003", Date: "2026-03-12", Amount: amount("-50"), Currency: "CZK", Message: "Poplatek"},
+		}},
+		{"statement_cp1250.csv", FormatCSV, []Transaction{
+			{Date: "2026-03-10", Amount: amount("1234.5"), Currency: "EUR", VariableSymbol: "20260003", CounterpartyName: "Gama GmbH", Message: "Zahlung"},
+		}},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(filepath.Join("testdata", tt.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := DetectFormat(data); got != tt.format {
+			t.Errorf("%s: detected %q, want %q", tt.file, got, tt.format)
+		}
+		got, err := Parse(data, "")
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: %d transactions, want %d: %+v", tt.file, len(got), len(tt.want), got)
+			continue
+		}
+		for i := range got {
+			if !sameTransaction(got[i], tt.want[i]) {
+				t.Errorf("%s: transaction %d =\n%+v\nwant\n%+v", tt.file, i+1, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+// sameTransaction compares transactions with amounts compared by value.
+func sameTransaction(a, b Transaction) bool {
+	if a.Amount.Cmp(b.Amount) != 0 {
+		return false
+	}
+	a.Amount, b.Amount = fakturoid.Amount{}, fakturoid.Amount{}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"short GPC record", "075123\n", FormatGPC},
+		{"CSV without header", "a;b;c\n1;2;3\n", FormatCSV},
+		{"CSV amount", "Datum;Objem\n10.03.2026;abc\n", FormatCSV},
+		{"CAMT", "<Document><BkToCstmrStmt>", FormatCAMT},
+		{"format", "", "mt940"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse([]byte(tt.data), tt.format); err == nil {
+			t.Errorf("%s: parsed without error", tt.name)
+		}
+	}
+}
+
+func TestParseCSVAmount(t *testing.T) {
+	tests := map[string]string{
+		"1 234,50": "1234.5",
+		"-1234.50": "-1234.5",
+		"1.234,50": "1234.5",
+		"1,234.50": "1234.5",
+		"+500":     "500",
+		"1 000,00": "1000",
+	}
+	for in, want := range tests {
+		got, err := parseCSVAmount(in)
+		if err != nil || got.String() != want {
+			t.Errorf("parseCSVAmount(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package company
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultARESURL is the ARES REST API for economic subjects.
+const DefaultARESURL = "https://ares.gov.cz/ekonomicke-subjekty-v-be/rest"
+
+// ARES queries the Czech business registry (Administrativní registr ekonomických subjektů).
+type ARES struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+func NewARES() *ARES {
+	return &ARES{
+		BaseURL:    DefaultARESURL,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type aresSubject struct {
+	ICO          string `json:"ico"`
+	Name         string `json:"obchodniJmeno"`
+	DIC          string `json:"dic"`
+	Headquarters struct {
+		Country      string `json:"kodStatu"`
+		City         string `json:"nazevObce"`
+		CityPart     string `json:"nazevCastiObce"`
+		Street       string `json:"nazevUlice"`
+		HouseNo      int    `json:"cisloDomovni"`
+		OrientNo     int    `json:"cisloOrientacni"`
+		OrientLetter string `json:"cisloOrientacniPismeno"`
+		Zip          int    `json:"psc"`
+	} `json:"sidlo"`
+	Registrations struct {
+		VAT string `json:"stavZdrojeDph"`
+	} `json:"seznamRegistraci"`
+}
+
+func (a *ARES) Lookup(ctx context.Context, registrationNo string) (*Info, error) {
+	ico, err := NormalizeRegistrationNo(registrationNo)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/ekonomicke-subjekty/%s", strings.TrimRight(a.BaseURL, "/"), ico)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create ARES request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ARES request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read ARES response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ARES error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var s aresSubject
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal ARES response: %w", err)
+	}
+	return s.info(), nil
+}
+
+func (s aresSubject) info() *Info {
+	h := s.Headquarters
+	info := &Info{
+		RegistrationNo: s.ICO,
+		Name:           s.Name,
+		VATNo:          s.DIC,
+		City:           h.City,
+		Country:        h.Country,
+		Source:         "ARES",
+	}
+	if h.Zip != 0 {
+		info.Zip = fmt.Sprintf("%05d", h.Zip)
+	}
+
+	// Czech addresses are "Street descriptive/orientation", or "CityPart descriptive"
+	// where there are no street names.
+	number := ""
+	if h.HouseNo != 0 {
+		number = fmt.Sprintf("%d", h.HouseNo)
+	}
+	if h.OrientNo != 0 {
+		number += fmt.Sprintf("/%d%s", h.OrientNo, h.OrientLetter)
+	}
+	street := h.Street
+	if street == "" {
+		street = h.CityPart
+	}
+	info.Street = strings.TrimSpace(street + " " + number)
+
+	switch s.Registrations.VAT {
+	case "AKTIVNI":
+		payer := true
+		info.VATPayer = &payer
+	case "":
+	default:
+		payer := false
+		info.VATPayer = &payer
+	}
+	return info
+}
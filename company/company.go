@@ -0,0 +1,66 @@
+// Package company looks up companies in public business registries.
+package company
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrNotFound = errors.New("company not found in registry")
+
+// Info is a registry record.
+type Info struct {
+	RegistrationNo string `json:"registration_no"`
+	Name           string `json:"name"`
+	VATNo          string `json:"vat_no,omitempty"`
+	// VATPayer is nil when the registry does not say.
+	VATPayer *bool  `json:"vat_payer,omitempty"`
+	Street   string `json:"street,omitempty"`
+	City     string `json:"city,omitempty"`
+	Zip      string `json:"zip,omitempty"`
+	Country  string `json:"country,omitempty"`
+	Source   string `json:"source"`
+}
+
+// Registry looks up a company by registration number (IČO).
+type Registry interface {
+	Lookup(ctx context.Context, registrationNo string) (*Info, error)
+}
+
+// NormalizeRegistrationNo strips spaces and pads a Czech IČO to eight digits, checking
+// its mod-11 check digit.
+func NormalizeRegistrationNo(ico string) (string, error) {
+	ico = strings.ReplaceAll(ico, " ", "")
+	if ico == "" || len(ico) > 8 || strings.Trim(ico, "0123456789") != "" {
+		return "", fmt.Errorf("invalid IČO %q", ico)
+	}
+	ico = strings.Repeat("0", 8-len(ico)) + ico
+
+	sum := 0
+	for i := 0; i < 7; i++ {
+		sum += int(ico[i]-'0') * (8 - i)
+	}
+	check := (11 - sum%11) % 10
+	if int(ico[7]-'0') != check {
+		return "", fmt.Errorf("invalid IČO %s (check digit)", ico)
+	}
+	return ico, nil
+}
+
+// Static is an in-memory registry, useful as a local fake. Keys are eight-digit IČOs;
+// lookups are normalised and validated like ARES does.
+type Static map[string]Info
+
+func (s Static) Lookup(ctx context.Context, registrationNo string) (*Info, error) {
+	ico, err := NormalizeRegistrationNo(registrationNo)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := s[ico]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &info, nil
+}
@@ -0,0 +1,52 @@
+package company
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNormalizeRegistrationNo(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"27074358", "27074358", false},
+		{"270 74 358", "27074358", false},
+		{"6947", "00006947", false},
+		{"27074359", "", true},
+		{"123456789", "", true},
+		{"CZ27074358", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeRegistrationNo(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeRegistrationNo(%q) = %q, %v; want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestStaticLookup(t *testing.T) {
+	s := Static{
+		"27074358": {RegistrationNo: "27074358", Name: "Alfa s.r.o."},
+		"00006947": {RegistrationNo: "00006947", Name: "Ministerstvo financí"},
+	}
+	for _, ico := range []string{"27074358", " 270 743 58", "6947"} {
+		info, err := s.Lookup(context.Background(), ico)
+		if err != nil {
+			t.Errorf("Lookup(%q): %v", ico, err)
+			continue
+		}
+		if want, _ := NormalizeRegistrationNo(ico); info.RegistrationNo != want {
+			t.Errorf("Lookup(%q) = %s", ico, info.RegistrationNo)
+		}
+	}
+	if _, err := s.Lookup(context.Background(), "25596641"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown IČO: error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Lookup(context.Background(), "27074359"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("invalid IČO: error = %v, want a validation error", err)
+	}
+}
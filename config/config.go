@@ -5,12 +5,39 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 type Config struct {
 	ClientID     string `json:"client_id"`
 	ClientSecret string `json:"client_secret"`
 	Slug         string `json:"slug"`
+
+	// Optional webhook receiver. Enabled when WebhookListen is set (e.g. ":8080").
+	WebhookListen string `json:"webhook_listen,omitempty"`
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+	WebhookStore  string `json:"webhook_store,omitempty"`
+
+	// ARESURL overrides the ARES registry endpoint, e.g. to point at a local fake.
+	ARESURL string `json:"ares_url,omitempty"`
+	// VIESURL and ADISURL override the VAT number registers.
+	VIESURL string `json:"vies_url,omitempty"`
+	ADISURL string `json:"adis_url,omitempty"`
+
+	// CNBURL overrides the exchange rate source; RatesCache is where fixings are kept
+	// (default: the user cache directory).
+	CNBURL     string `json:"cnb_url,omitempty"`
+	RatesCache string `json:"rates_cache,omitempty"`
+
+	// ReminderTemplates is a JSON file overriding the payment reminder templates.
+	ReminderTemplates string `json:"reminder_templates,omitempty"`
+	// EmailTemplates is a JSON file with named invoice email templates.
+	EmailTemplates string `json:"email_templates,omitempty"`
+
+	// CacheTTL is how long API responses are reused, as a Go duration ("0" disables the
+	// cache, default 1m). CacheDir keeps them across restarts.
+	CacheTTL string `json:"cache_ttl,omitempty"`
+	CacheDir string `json:"cache_dir,omitempty"`
 }
 
 const configDir = "fakturoid-mcp"
@@ -36,6 +63,42 @@ func Load() (*Config, error) {
 	if v := os.Getenv("FAKTUROID_SLUG"); v != "" {
 		cfg.Slug = v
 	}
+	if v := os.Getenv("FAKTUROID_WEBHOOK_LISTEN"); v != "" {
+		cfg.WebhookListen = v
+	}
+	if v := os.Getenv("FAKTUROID_WEBHOOK_SECRET"); v != "" {
+		cfg.WebhookSecret = v
+	}
+	if v := os.Getenv("FAKTUROID_WEBHOOK_STORE"); v != "" {
+		cfg.WebhookStore = v
+	}
+	if v := os.Getenv("FAKTUROID_ARES_URL"); v != "" {
+		cfg.ARESURL = v
+	}
+	if v := os.Getenv("FAKTUROID_VIES_URL"); v != "" {
+		cfg.VIESURL = v
+	}
+	if v := os.Getenv("FAKTUROID_ADIS_URL"); v != "" {
+		cfg.ADISURL = v
+	}
+	if v := os.Getenv("FAKTUROID_CNB_URL"); v != "" {
+		cfg.CNBURL = v
+	}
+	if v := os.Getenv("FAKTUROID_RATES_CACHE"); v != "" {
+		cfg.RatesCache = v
+	}
+	if v := os.Getenv("FAKTUROID_REMINDER_TEMPLATES"); v != "" {
+		cfg.ReminderTemplates = v
+	}
+	if v := os.Getenv("FAKTUROID_EMAIL_TEMPLATES"); v != "" {
+		cfg.EmailTemplates = v
+	}
+	if v := os.Getenv("FAKTUROID_CACHE_TTL"); v != "" {
+		cfg.CacheTTL = v
+	}
+	if v := os.Getenv("FAKTUROID_CACHE_DIR"); v != "" {
+		cfg.CacheDir = v
+	}
 
 	if cfg.ClientID == "" || cfg.ClientSecret == "" {
 		return nil, fmt.Errorf("FAKTUROID_CLIENT_ID and FAKTUROID_CLIENT_SECRET required (use env variables or ~/.config/%s/%s)", configDir, configFile)
@@ -43,6 +106,14 @@ func Load() (*Config, error) {
 	if cfg.Slug == "" {
 		return nil, fmt.Errorf("FAKTUROID_SLUG required (your Fakturoid account slug)")
 	}
+	if cfg.CacheTTL != "" {
+		if _, err := time.ParseDuration(cfg.CacheTTL); err != nil {
+			return nil, fmt.Errorf("invalid cache_ttl %q: use a duration such as 30s or 5m", cfg.CacheTTL)
+		}
+	}
+	if cfg.WebhookListen != "" && cfg.WebhookSecret == "" {
+		return nil, fmt.Errorf("FAKTUROID_WEBHOOK_SECRET required when the webhook receiver is enabled")
+	}
 
 	return cfg, nil
 }
@@ -0,0 +1,56 @@
+package emailtpl
+
+// Defaults are the built-in templates: "invoice" for a new invoice and "proforma" for
+// a request for advance payment.
+var Defaults = Set{
+	Templates: map[string]map[string]Template{
+		"invoice": {
+			Czech: {
+				Subject: "Faktura {{.Number}}",
+				Body: `Dobrý den,
+
+zasíláme Vám fakturu {{.Number}} na částku {{.Total}} se splatností {{.DueOn}}.
+
+Fakturu si můžete zobrazit a stáhnout zde: {{.Link}}
+
+S pozdravem
+{{.Sender}}`,
+			},
+			English: {
+				Subject: "Invoice {{.Number}}",
+				Body: `Hello,
+
+please find invoice {{.Number}} for {{.Total}}, due on {{.DueOn}}.
+
+You can view and download it here: {{.Link}}
+
+Kind regards
+{{.Sender}}`,
+			},
+		},
+		"proforma": {
+			Czech: {
+				Subject: "Zálohová faktura {{.Number}}",
+				Body: `Dobrý den,
+
+zasíláme Vám zálohovou fakturu {{.Number}} na částku {{.Total}} se splatností {{.DueOn}}. Po jejím uhrazení Vám vystavíme daňový doklad.
+
+Zálohovou fakturu najdete zde: {{.Link}}
+
+S pozdravem
+{{.Sender}}`,
+			},
+			English: {
+				Subject: "Proforma invoice {{.Number}}",
+				Body: `Hello,
+
+please find proforma invoice {{.Number}} for {{.Total}}, due on {{.DueOn}}. We will issue a tax document once it is paid.
+
+You can view it here: {{.Link}}
+
+Kind regards
+{{.Sender}}`,
+			},
+		},
+	},
+}
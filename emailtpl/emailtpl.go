@@ -0,0 +1,238 @@
+// Package emailtpl renders invoice emails from named, per-language templates.
+package emailtpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Languages with built-in templates.
+const (
+	Czech   = "cs"
+	English = "en"
+)
+
+// Template is an email subject and body as text/template strings over Data.
+type Template struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Set holds named templates, each in one or more languages, and the language chosen
+// for particular subjects.
+type Set struct {
+	Templates map[string]map[string]Template `json:"templates"`
+	// SubjectLanguages maps subject IDs to the language of their emails.
+	SubjectLanguages map[string]string `json:"subject_languages,omitempty"`
+}
+
+// Data is what templates can use. Amounts and dates are formatted for the email's language.
+type Data struct {
+	ClientName     string
+	Number         string
+	Total          string
+	Remaining      string
+	IssuedOn       string
+	DueOn          string
+	VariableSymbol string
+	// Link is the invoice's public page; Fakturoid's #link# placeholder when unknown.
+	Link   string
+	Sender string
+}
+
+// Email is a rendered template.
+type Email struct {
+	Template string `json:"template"`
+	Language string `json:"language"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
+
+// Load reads a Set from JSON. Templates in the file are added to the built-in ones;
+// a template of the same name replaces the built-in one entirely.
+func Load(path string) (*Set, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read email templates: %w", err)
+	}
+	var custom Set
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("parse email templates: %w", err)
+	}
+
+	set := &Set{Templates: map[string]map[string]Template{}, SubjectLanguages: custom.SubjectLanguages}
+	if set.SubjectLanguages == nil {
+		set.SubjectLanguages = map[string]string{}
+	}
+	for name, langs := range Defaults.Templates {
+		set.Templates[name] = langs
+	}
+	for name, langs := range custom.Templates {
+		if len(langs) == 0 {
+			return nil, fmt.Errorf("email template %q has no languages", name)
+		}
+		normalized := map[string]Template{}
+		for lang, t := range langs {
+			if err := Validate(t); err != nil {
+				return nil, fmt.Errorf("email template %s (%s): %w", name, lang, err)
+			}
+			normalized[strings.ToLower(lang)] = t
+		}
+		set.Templates[name] = normalized
+	}
+	return set, nil
+}
+
+// Names lists the template names.
+func (s *Set) Names() []string {
+	names := make([]string, 0, len(s.Templates))
+	for name := range s.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Language picks the email language for an invoice: the subject's configured language,
+// then the invoice language, then Czech for Czech and Slovak clients and English otherwise.
+func (s *Set) Language(inv fakturoid.Invoice) string {
+	if lang := s.SubjectLanguages[fmt.Sprint(inv.SubjectID)]; lang != "" {
+		return strings.ToLower(lang)
+	}
+	return Language(inv)
+}
+
+// Language picks Czech for invoices in Czech or Slovak, English otherwise.
+func Language(inv fakturoid.Invoice) string {
+	switch strings.ToLower(inv.Language) {
+	case "cz", "cs", "sk":
+		return Czech
+	case "":
+		if c := strings.ToUpper(inv.ClientCountry); c == "" || c == "CZ" || c == "SK" {
+			return Czech
+		}
+	}
+	return English
+}
+
+// Render renders template name for an invoice. When the template lacks lang, English
+// is used, then any language it has.
+func (s *Set) Render(name, lang string, inv fakturoid.Invoice, sender string) (Email, error) {
+	langs, ok := s.Templates[name]
+	if !ok {
+		return Email{}, fmt.Errorf("unknown email template %q (available: %s)", name, strings.Join(s.Names(), ", "))
+	}
+	// Fall back to English, then Czech, then the first language alphabetically.
+	fallback := []string{lang, English, Czech}
+	for l := range langs {
+		fallback = append(fallback, l)
+	}
+	sort.Strings(fallback[3:])
+	var t Template
+	for _, l := range fallback {
+		if tpl, ok := langs[l]; ok {
+			lang, t = l, tpl
+			break
+		}
+	}
+
+	subject, body, err := Execute(t, NewData(lang, inv, sender))
+	if err != nil {
+		return Email{}, fmt.Errorf("email template %s (%s): %w", name, lang, err)
+	}
+	return Email{Template: name, Language: lang, Subject: subject, Body: body}, nil
+}
+
+// NewData fills template data from an invoice.
+func NewData(lang string, inv fakturoid.Invoice, sender string) Data {
+	d := Data{
+		ClientName:     inv.ClientName,
+		Number:         inv.Number,
+		Total:          FormatMoney(lang, fakturoid.NewMoney(inv.Total, inv.Currency)),
+		Remaining:      FormatMoney(lang, fakturoid.NewMoney(inv.RemainingAmount, inv.Currency)),
+		IssuedOn:       FormatDate(lang, inv.IssuedOn),
+		DueOn:          FormatDate(lang, inv.DueOn),
+		VariableSymbol: inv.VariableSymbol,
+		Link:           inv.PublicHTMLURL,
+		Sender:         sender,
+	}
+	if d.ClientName == "" {
+		d.ClientName = inv.SubjectName
+	}
+	if d.Link == "" {
+		d.Link = "#link#"
+	}
+	return d
+}
+
+// Execute renders a template's subject and body with any data value.
+func Execute(t Template, data any) (string, string, error) {
+	subject, err := template.New("subject").Option("missingkey=error").Parse(t.Subject)
+	if err != nil {
+		return "", "", fmt.Errorf("subject: %w", err)
+	}
+	body, err := template.New("body").Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("body: %w", err)
+	}
+	var sb, bb strings.Builder
+	if err := subject.Execute(&sb, data); err != nil {
+		return "", "", fmt.Errorf("subject: %w", err)
+	}
+	if err := body.Execute(&bb, data); err != nil {
+		return "", "", fmt.Errorf("body: %w", err)
+	}
+	return strings.TrimSpace(sb.String()), strings.TrimSpace(bb.String()), nil
+}
+
+// Validate renders a template with empty data, which catches syntax errors and
+// unknown fields.
+func Validate(t Template) error {
+	_, _, err := Execute(t, Data{})
+	return err
+}
+
+// FormatDate renders YYYY-MM-DD as "1. 9. 2026" in Czech and "1 September 2026" otherwise.
+func FormatDate(lang, date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	if lang == Czech {
+		return fmt.Sprintf("%d. %d. %d", t.Day(), t.Month(), t.Year())
+	}
+	return t.Format("2 January 2006")
+}
+
+// FormatMoney groups thousands: "12 345,60 CZK" in Czech, "12,345.60 EUR" otherwise.
+func FormatMoney(lang string, m fakturoid.Money) string {
+	s := m.Amount.StringFixed(fakturoid.CurrencyDecimals(m.Currency))
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	group, point := ",", "."
+	if lang == Czech {
+		group, point = " ", ","
+	}
+	var b strings.Builder
+	for i, c := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteRune(c)
+	}
+	result := sign + b.String()
+	if frac != "" {
+		result += point + frac
+	}
+	return strings.TrimSpace(result + " " + m.Currency)
+}
@@ -0,0 +1,38 @@
+package emailtpl
+
+import (
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func TestRenderLanguageFallback(t *testing.T) {
+	tpl := func(subject string) Template { return Template{Subject: subject, Body: "{{.Number}}"} }
+	s := &Set{Templates: map[string]map[string]Template{
+		"all":   {"cs": tpl("cs"), "en": tpl("en"), "de": tpl("de")},
+		"czech": {"cs": tpl("cs"), "sk": tpl("sk"), "de": tpl("de")},
+		"other": {"sk": tpl("sk"), "pl": tpl("pl"), "de": tpl("de")},
+	}}
+	tests := []struct {
+		name, lang, want string
+	}{
+		{"all", "de", "de"},
+		{"all", "fr", "en"},
+		{"czech", "fr", "cs"},
+		{"other", "fr", "de"},
+		{"other", "pl", "pl"},
+	}
+	inv := fakturoid.Invoice{Number: "2026-0001"}
+	for _, tt := range tests {
+		// Map order varies between runs; the fallback must not.
+		for range 20 {
+			email, err := s.Render(tt.name, tt.lang, inv, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if email.Language != tt.want || email.Subject != tt.want || email.Body != "2026-0001" {
+				t.Fatalf("%s in %s: got %s %+v, want %s", tt.name, tt.lang, email.Language, email, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+var InvoiceColumns = []Column[fakturoid.Invoice]{
+	{"id", func(i fakturoid.Invoice) Cell { return Int(i.ID) }},
+	{"number", func(i fakturoid.Invoice) Cell { return Text(i.Number) }},
+	{"document_type", func(i fakturoid.Invoice) Cell { return Text(i.DocumentType) }},
+	{"status", func(i fakturoid.Invoice) Cell { return Text(i.Status) }},
+	{"subject_id", func(i fakturoid.Invoice) Cell { return Int(i.SubjectID) }},
+	{"client_name", func(i fakturoid.Invoice) Cell { return Text(firstNonEmpty(i.ClientName, i.SubjectName)) }},
+	{"client_registration_no", func(i fakturoid.Invoice) Cell { return Text(i.ClientRegistrationNo) }},
+	{"client_vat_no", func(i fakturoid.Invoice) Cell { return Text(i.ClientVATNo) }},
+	{"issued_on", func(i fakturoid.Invoice) Cell { return Date(i.IssuedOn) }},
+	{"taxable_fulfillment_due", func(i fakturoid.Invoice) Cell { return Date(i.TaxableFulfillmentDue) }},
+	{"due_on", func(i fakturoid.Invoice) Cell { return Date(i.DueOn) }},
+	{"paid_on", func(i fakturoid.Invoice) Cell { return Date(i.PaidOn) }},
+	{"currency", func(i fakturoid.Invoice) Cell { return Text(i.Currency) }},
+	{"exchange_rate", func(i fakturoid.Invoice) Cell { return Number(i.ExchangeRate) }},
+	{"subtotal", func(i fakturoid.Invoice) Cell { return Number(i.Subtotal) }},
+	{"total", func(i fakturoid.Invoice) Cell { return Number(i.Total) }},
+	{"native_subtotal", func(i fakturoid.Invoice) Cell { return Number(i.NativeSubtotal) }},
+	{"native_total", func(i fakturoid.Invoice) Cell { return Number(i.NativeTotal) }},
+	{"remaining_amount", func(i fakturoid.Invoice) Cell { return Number(i.RemainingAmount) }},
+	{"variable_symbol", func(i fakturoid.Invoice) Cell { return Text(i.VariableSymbol) }},
+	{"payment_method", func(i fakturoid.Invoice) Cell { return Text(i.PaymentMethod) }},
+	{"tags", func(i fakturoid.Invoice) Cell { return Text(strings.Join(i.Tags, ", ")) }},
+	{"note", func(i fakturoid.Invoice) Cell { return Text(i.Note) }},
+}
+
+var DefaultInvoiceColumns = []string{
+	"number", "status", "client_name", "client_registration_no", "issued_on", "taxable_fulfillment_due",
+	"due_on", "paid_on", "currency", "subtotal", "total", "native_total", "remaining_amount",
+}
+
+var ExpenseColumns = []Column[fakturoid.Expense]{
+	{"id", func(e fakturoid.Expense) Cell { return Int(e.ID) }},
+	{"number", func(e fakturoid.Expense) Cell { return Text(e.Number) }},
+	{"original_number", func(e fakturoid.Expense) Cell { return Text(e.OriginalNumber) }},
+	{"status", func(e fakturoid.Expense) Cell { return Text(e.Status) }},
+	{"subject_id", func(e fakturoid.Expense) Cell { return Int(e.SubjectID) }},
+	{"supplier_name", func(e fakturoid.Expense) Cell { return Text(firstNonEmpty(e.SupplierName, e.SubjectName)) }},
+	{"supplier_vat_no", func(e fakturoid.Expense) Cell { return Text(e.SupplierVATNo) }},
+	{"issued_on", func(e fakturoid.Expense) Cell { return Date(e.IssuedOn) }},
+	{"taxable_fulfillment_due", func(e fakturoid.Expense) Cell { return Date(e.TaxableFulfillmentDue) }},
+	{"due_on", func(e fakturoid.Expense) Cell { return Date(e.DueOn) }},
+	{"paid_on", func(e fakturoid.Expense) Cell { return Date(e.PaidOn) }},
+	{"currency", func(e fakturoid.Expense) Cell { return Text(e.Currency) }},
+	{"exchange_rate", func(e fakturoid.Expense) Cell { return Number(e.ExchangeRate) }},
+	{"subtotal", func(e fakturoid.Expense) Cell { return Number(e.Subtotal) }},
+	{"total", func(e fakturoid.Expense) Cell { return Number(e.Total) }},
+	{"native_subtotal", func(e fakturoid.Expense) Cell { return Number(e.NativeSubtotal) }},
+	{"native_total", func(e fakturoid.Expense) Cell { return Number(e.NativeTotal) }},
+	{"tags", func(e fakturoid.Expense) Cell { return Text(strings.Join(e.Tags, ", ")) }},
+}
+
+var DefaultExpenseColumns = []string{
+	"number", "original_number", "status", "supplier_name", "issued_on", "taxable_fulfillment_due",
+	"due_on", "paid_on", "currency", "subtotal", "total", "native_total",
+}
+
+var SubjectColumns = []Column[fakturoid.Subject]{
+	{"id", func(s fakturoid.Subject) Cell { return Int(s.ID) }},
+	{"name", func(s fakturoid.Subject) Cell { return Text(s.Name) }},
+	{"full_name", func(s fakturoid.Subject) Cell { return Text(s.FullName) }},
+	{"type", func(s fakturoid.Subject) Cell { return Text(s.Type) }},
+	{"registration_no", func(s fakturoid.Subject) Cell { return Text(s.RegistrationNo) }},
+	{"vat_no", func(s fakturoid.Subject) Cell { return Text(s.VATNo) }},
+	{"street", func(s fakturoid.Subject) Cell { return Text(s.Street) }},
+	{"city", func(s fakturoid.Subject) Cell { return Text(s.City) }},
+	{"zip", func(s fakturoid.Subject) Cell { return Text(s.Zip) }},
+	{"country", func(s fakturoid.Subject) Cell { return Text(s.Country) }},
+	{"email", func(s fakturoid.Subject) Cell { return Text(s.Email) }},
+	{"phone", func(s fakturoid.Subject) Cell { return Text(s.Phone) }},
+}
+
+var DefaultSubjectColumns = []string{
+	"id", "name", "registration_no", "vat_no", "street", "city", "zip", "country", "email",
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
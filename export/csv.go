@@ -0,0 +1,49 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type CSVOptions struct {
+	// Delimiter separates fields (default ',').
+	Delimiter rune
+	// DecimalComma writes numbers with a decimal comma and prefixes a UTF-8 BOM,
+	// which is what Czech Excel expects.
+	DecimalComma bool
+}
+
+func WriteCSV(w io.Writer, t *Table, opts CSVOptions) error {
+	if opts.Delimiter == 0 {
+		opts.Delimiter = ','
+	}
+	if opts.DecimalComma {
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return err
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opts.Delimiter
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(t.Columns))
+	for _, row := range t.Rows {
+		for i, c := range row {
+			record[i] = c.text
+			if c.kind == kindNumber {
+				record[i] = c.number.String()
+				if opts.DecimalComma {
+					record[i] = strings.Replace(record[i], ".", ",", 1)
+				}
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,102 @@
+// Package export writes Fakturoid documents as CSV and XLSX spreadsheets.
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+type cellKind int
+
+const (
+	kindText cellKind = iota
+	kindNumber
+	kindDate
+)
+
+// Cell is a typed value, so XLSX can store numbers and dates natively.
+type Cell struct {
+	kind   cellKind
+	text   string
+	number fakturoid.Amount
+	date   time.Time
+}
+
+func Text(s string) Cell { return Cell{kind: kindText, text: s} }
+
+func Number(a fakturoid.Amount) Cell { return Cell{kind: kindNumber, number: a} }
+
+func Int(i int) Cell {
+	if i == 0 {
+		return Text("")
+	}
+	return Number(fakturoid.NewAmount(int64(i)))
+}
+
+// Date parses a YYYY-MM-DD date; other values are kept as text.
+func Date(s string) Cell {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return Text(s)
+	}
+	return Cell{kind: kindDate, text: s, date: t}
+}
+
+// Table is a header row and data rows.
+type Table struct {
+	Columns []string
+	Rows    [][]Cell
+}
+
+// Column extracts one value from a record.
+type Column[T any] struct {
+	Name  string
+	Value func(T) Cell
+}
+
+// Build selects columns by name (all defaults when names is empty) and fills the table.
+func Build[T any](records []T, columns []Column[T], defaults, names []string) (*Table, error) {
+	if len(names) == 0 {
+		names = defaults
+	}
+	byName := make(map[string]Column[T], len(columns))
+	for _, c := range columns {
+		byName[c.Name] = c
+	}
+
+	selected := make([]Column[T], 0, len(names))
+	for _, n := range names {
+		c, ok := byName[strings.TrimSpace(n)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)", n, strings.Join(ColumnNames(columns), ", "))
+		}
+		selected = append(selected, c)
+	}
+
+	t := &Table{}
+	for _, c := range selected {
+		t.Columns = append(t.Columns, c.Name)
+	}
+	for _, rec := range records {
+		row := make([]Cell, len(selected))
+		for i, c := range selected {
+			row[i] = c.Value(rec)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t, nil
+}
+
+// ColumnNames lists the column names in alphabetical order.
+func ColumnNames[T any](columns []Column[T]) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,85 @@
+package exrate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cache keeps fixings in memory and, when Dir is set, in JSON files so each day is
+// fetched only once. A fixing is stored only once it is final: for a past day, or for
+// today after it has been published.
+type Cache struct {
+	Provider Provider
+	Dir      string
+
+	mu     sync.Mutex
+	memory map[string]*Fixing
+}
+
+func NewCache(p Provider, dir string) *Cache {
+	return &Cache{Provider: p, Dir: dir, memory: map[string]*Fixing{}}
+}
+
+func (c *Cache) Fixing(ctx context.Context, date time.Time) (*Fixing, error) {
+	key := date.Format(dateLayout)
+
+	c.mu.Lock()
+	f, ok := c.memory[key]
+	c.mu.Unlock()
+	if ok {
+		return f, nil
+	}
+
+	path := ""
+	if c.Dir != "" {
+		path = filepath.Join(c.Dir, "fixing-"+key+".json")
+		if data, err := os.ReadFile(path); err == nil {
+			var cached Fixing
+			if err := json.Unmarshal(data, &cached); err == nil {
+				c.remember(key, &cached)
+				return &cached, nil
+			}
+		}
+	}
+
+	f, err := c.Provider.Fixing(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().Format(dateLayout)
+	if key >= today && f.Date != key {
+		// Today's fixing is not out yet; the previous one may still be replaced.
+		return f, nil
+	}
+	c.remember(key, f)
+	if path != "" {
+		// The file cache only saves requests; the rates are valid either way.
+		_ = c.store(path, f)
+	}
+	return f, nil
+}
+
+func (c *Cache) remember(key string, f *Fixing) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.memory[key] = f
+}
+
+func (c *Cache) store(path string, f *Fixing) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("marshal fixing: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create rate cache: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write rate cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package exrate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// DefaultCNBURL is the Czech National Bank's daily fixing in text form.
+const DefaultCNBURL = "https://www.cnb.cz/cs/financni-trhy/devizovy-trh/kurzy-devizoveho-trhu/kurzy-devizoveho-trhu/denni_kurz.txt"
+
+// CNB fetches the daily exchange rate fixing of the Czech National Bank. Rates are
+// quoted in CZK and published on working days around 14:30.
+type CNB struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func NewCNB() *CNB {
+	return &CNB{
+		URL:        DefaultCNBURL,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (c *CNB) Fixing(ctx context.Context, date time.Time) (*Fixing, error) {
+	url := fmt.Sprintf("%s?date=%s", c.URL, date.Format("02.01.2006"))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create CNB request: %w", err)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("CNB request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read CNB response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CNB error (%d): %s", resp.StatusCode, string(body))
+	}
+	return ParseCNB(body)
+}
+
+// ParseCNB parses the CNB daily fixing:
+//
+//	18.10.2024 #202
+//	země|měna|množství|kód|kurz
+//	EMU|euro|1|EUR|25,340
+//	Japonsko|jen|100|JPY|15,523
+//
+// The English variant ("18 Oct 2024", decimal points) is accepted too.
+func ParseCNB(data []byte) (*Fixing, error) {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	if !sc.Scan() {
+		return nil, fmt.Errorf("CNB fixing is empty")
+	}
+	header, _, _ := strings.Cut(sc.Text(), "#")
+	header = strings.TrimSpace(header)
+	day, err := time.Parse("02.01.2006", header)
+	if err != nil {
+		if day, err = time.Parse("02 Jan 2006", header); err != nil {
+			return nil, fmt.Errorf("CNB fixing: invalid date line %q", sc.Text())
+		}
+	}
+
+	f := &Fixing{Date: day.Format(dateLayout), Source: "CNB", Base: "CZK", Rates: map[string]fakturoid.Amount{}}
+	line := 1
+	for sc.Scan() {
+		line++
+		fields := strings.Split(strings.TrimSpace(sc.Text()), "|")
+		if line == 2 || len(fields) != 5 {
+			continue
+		}
+		quantity, err := strconv.Atoi(fields[2])
+		if err != nil || quantity <= 0 {
+			return nil, fmt.Errorf("CNB fixing line %d: invalid amount %q", line, fields[2])
+		}
+		rate, err := fakturoid.ParseAmount(strings.ReplaceAll(fields[4], ",", "."))
+		if err != nil {
+			return nil, fmt.Errorf("CNB fixing line %d: %w", line, err)
+		}
+		if rate, err = rate.Div(fakturoid.NewAmount(int64(quantity))); err != nil {
+			return nil, fmt.Errorf("CNB fixing line %d: %w", line, err)
+		}
+		f.Rates[strings.ToUpper(fields[3])] = rate
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("read CNB fixing: %w", err)
+	}
+	if len(f.Rates) == 0 {
+		return nil, fmt.Errorf("CNB fixing of %s has no rates", f.Date)
+	}
+	return f, nil
+}
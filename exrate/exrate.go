@@ -0,0 +1,76 @@
+// Package exrate provides exchange rates for foreign-currency documents.
+package exrate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+const dateLayout = "2006-01-02"
+
+// Fixing is a table of exchange rates published for one day.
+type Fixing struct {
+	// Date is the day the rates were published; it may precede the requested day
+	// on weekends and holidays.
+	Date   string `json:"date"`
+	Source string `json:"source"`
+	// Base is the currency the rates are quoted in.
+	Base string `json:"base"`
+	// Rates holds units of Base per one unit of each currency.
+	Rates map[string]fakturoid.Amount `json:"rates"`
+}
+
+// Rate returns units of to per one unit of from, crossing through the base currency
+// when neither of them is the base.
+func (f *Fixing) Rate(from, to string) (fakturoid.Amount, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return fakturoid.NewAmount(1), nil
+	}
+	fromBase, err := f.baseRate(from)
+	if err != nil {
+		return fakturoid.Amount{}, err
+	}
+	toBase, err := f.baseRate(to)
+	if err != nil {
+		return fakturoid.Amount{}, err
+	}
+	rate, err := fromBase.Div(toBase)
+	if err != nil {
+		return fakturoid.Amount{}, err
+	}
+	return rate.Round(6, fakturoid.RoundHalfUp), nil
+}
+
+func (f *Fixing) baseRate(currency string) (fakturoid.Amount, error) {
+	if currency == f.Base {
+		return fakturoid.NewAmount(1), nil
+	}
+	rate, ok := f.Rates[currency]
+	if !ok || rate.IsZero() {
+		return fakturoid.Amount{}, fmt.Errorf("%s fixing of %s has no rate for %s", f.Source, f.Date, currency)
+	}
+	return rate, nil
+}
+
+// Provider returns exchange rates for a day.
+type Provider interface {
+	// Fixing returns the rates valid on date: the latest fixing published on or before it.
+	Fixing(ctx context.Context, date time.Time) (*Fixing, error)
+}
+
+// Fixed is a provider returning the same rates for every day, quoted in CZK. It is
+// meant as a local fake.
+type Fixed map[string]fakturoid.Amount
+
+func (f Fixed) Fixing(ctx context.Context, date time.Time) (*Fixing, error) {
+	rates := make(map[string]fakturoid.Amount, len(f))
+	for currency, rate := range f {
+		rates[strings.ToUpper(currency)] = rate
+	}
+	return &Fixing{Date: date.Format(dateLayout), Source: "fixed", Base: "CZK", Rates: rates}, nil
+}
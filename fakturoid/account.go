@@ -11,3 +11,28 @@ func (c *Client) GetEvents(page int) ([]Event, error) {
 	err := c.do("GET", "/events.json", nil, &result)
 	return result, err
 }
+
+// VATPayer reports whether the account charges VAT on domestic invoices.
+// Unknown modes are treated as VAT payers.
+func (a Account) VATPayer() bool {
+	return a.VATMode != "non_vat_payer" && a.VATMode != "identified_person"
+}
+
+func (c *Client) GetBankAccounts() ([]BankAccount, error) {
+	var result []BankAccount
+	err := c.do("GET", "/bank_accounts.json", nil, &result)
+	return result, err
+}
+
+// GetNumberFormats lists invoice number formats.
+func (c *Client) GetNumberFormats() ([]NumberFormat, error) {
+	var result []NumberFormat
+	err := c.do("GET", "/number_formats/invoices.json", nil, &result)
+	return result, err
+}
+
+func (c *Client) GetUsers() ([]User, error) {
+	var result []User
+	err := c.do("GET", "/users.json", nil, &result)
+	return result, err
+}
@@ -0,0 +1,265 @@
+package fakturoid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// relatedResources lists resources whose cached responses also change when another
+// resource is modified, e.g. issuing stock on an invoice line.
+var relatedResources = map[string][]string{
+	"invoices":        {"inventory_items", "inventory_moves"},
+	"expenses":        {"inventory_items", "inventory_moves"},
+	"inventory_moves": {"inventory_items"},
+	"inventory_items": {"inventory_moves"},
+	"inbox_files":     {"expenses"},
+}
+
+// Cache keeps GET responses for a TTL, keyed by account and endpoint. After the TTL a
+// response is revalidated with If-None-Match when the API sent an ETag, so an unchanged
+// document is not transferred again. Successful mutating requests drop the cached responses
+// of the resource they touch. When Dir is set, responses are also kept in files and survive
+// restarts. At most MaxEntries responses are kept; beyond that the ones that expired
+// first are evicted.
+type Cache struct {
+	TTL        time.Duration
+	Dir        string
+	MaxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	stats   CacheStats
+}
+
+type cacheEntry struct {
+	Account  string    `json:"account"`
+	Endpoint string    `json:"endpoint"`
+	Body     []byte    `json:"body"`
+	ETag     string    `json:"etag,omitempty"`
+	Expires  time.Time `json:"expires"`
+}
+
+// CacheStats counts cache use since the start. Misses are responses fetched in full,
+// Revalidated those the API confirmed unchanged.
+type CacheStats struct {
+	Hits          int `json:"hits"`
+	Misses        int `json:"misses"`
+	Revalidated   int `json:"revalidated"`
+	Invalidations int `json:"invalidations"`
+	Evictions     int `json:"evictions"`
+	Entries       int `json:"entries"`
+	Fresh         int `json:"fresh"`
+	// Resources counts the entries per resource, e.g. "invoices" or "account".
+	Resources map[string]int `json:"resources,omitempty"`
+}
+
+// DefaultCacheEntries is the MaxEntries of a cache made by NewCache.
+const DefaultCacheEntries = 1000
+
+// NewCache returns a cache keeping responses for ttl, loading those persisted in dir.
+func NewCache(ttl time.Duration, dir string) *Cache {
+	c := &Cache{TTL: ttl, Dir: dir, MaxEntries: DefaultCacheEntries, entries: map[string]*cacheEntry{}}
+	if dir == "" {
+		return c
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var e cacheEntry
+		if json.Unmarshal(data, &e) != nil || e.Account == "" {
+			continue
+		}
+		c.entries[cacheKey(e.Account, e.Endpoint)] = &e
+	}
+	c.evict()
+	return c
+}
+
+// SetCache enables response caching for the client; nil disables it.
+func (c *Client) SetCache(cache *Cache) {
+	c.cache = cache
+}
+
+// Cache returns the client's response cache, or nil when caching is off.
+func (c *Client) Cache() *Cache {
+	return c.cache
+}
+
+// InvalidateCache drops the account's cached responses of the given resources (e.g.
+// "invoices"), or all of them when none is given. Use it for changes made outside this
+// client, such as those reported by webhooks.
+func (c *Client) InvalidateCache(resources ...string) {
+	if c.cache == nil {
+		return
+	}
+	if len(resources) == 0 {
+		c.cache.invalidate(c.slug, "")
+		return
+	}
+	for _, r := range resources {
+		c.cache.invalidate(c.slug, r)
+	}
+}
+
+// Stats reports cache use and the entries currently held.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Resources = map[string]int{}
+	now := time.Now()
+	for _, e := range c.entries {
+		if now.Before(e.Expires) {
+			stats.Fresh++
+		}
+		stats.Resources[resourceOf(e.Endpoint)]++
+	}
+	return stats
+}
+
+// Clear drops all cached responses.
+func (c *Cache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		c.remove(key)
+	}
+}
+
+// lookup returns the cached response and whether it is still fresh.
+func (c *Cache) lookup(account, endpoint string) (*cacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[cacheKey(account, endpoint)]
+	if !ok || (e.ETag == "" && !time.Now().Before(e.Expires)) {
+		return nil, false
+	}
+	if time.Now().Before(e.Expires) {
+		c.stats.Hits++
+		return e, true
+	}
+	return e, false
+}
+
+func (c *Cache) store(account, endpoint string, body []byte, etag string) {
+	e := &cacheEntry{Account: account, Endpoint: endpoint, Body: body, ETag: etag, Expires: time.Now().Add(c.TTL)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := cacheKey(account, endpoint)
+	c.entries[key] = e
+	c.stats.Misses++
+	c.persist(key, e)
+	c.evict()
+}
+
+// evict trims the cache to MaxEntries. Expired entries without an ETag can never be used
+// again and go first, then those that expired or will expire first.
+func (c *Cache) evict() {
+	if c.MaxEntries <= 0 || len(c.entries) <= c.MaxEntries {
+		return
+	}
+	now := time.Now()
+	for key, e := range c.entries {
+		if e.ETag == "" && !now.Before(e.Expires) {
+			c.remove(key)
+			c.stats.Evictions++
+		}
+	}
+	if len(c.entries) <= c.MaxEntries {
+		return
+	}
+	keys := make([]string, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return c.entries[keys[i]].Expires.Before(c.entries[keys[j]].Expires) })
+	for _, key := range keys[:len(keys)-c.MaxEntries] {
+		c.remove(key)
+		c.stats.Evictions++
+	}
+}
+
+// revalidated extends an entry the API confirmed unchanged with 304 Not Modified and
+// returns its body. It reports false when the entry was invalidated in the meantime.
+func (c *Cache) revalidated(account, endpoint, etag string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := cacheKey(account, endpoint)
+	e, ok := c.entries[key]
+	if !ok || e.ETag != etag {
+		return nil, false
+	}
+	e.Expires = time.Now().Add(c.TTL)
+	c.stats.Revalidated++
+	c.persist(key, e)
+	return e.Body, true
+}
+
+// invalidate drops the account's entries of resource and its related resources, or all
+// the account's entries when resource is empty. The event log changes with every write,
+// so it is always dropped.
+func (c *Cache) invalidate(account, resource string) {
+	drop := map[string]bool{resource: true, "events": true}
+	for _, r := range relatedResources[resource] {
+		drop[r] = true
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.Invalidations++
+	for key, e := range c.entries {
+		if e.Account == account && (resource == "" || drop[resourceOf(e.Endpoint)]) {
+			c.remove(key)
+		}
+	}
+}
+
+func (c *Cache) remove(key string) {
+	delete(c.entries, key)
+	if c.Dir != "" {
+		_ = os.Remove(c.path(key))
+	}
+}
+
+// persist writes an entry to Dir. The files only save requests, so write errors are ignored.
+func (c *Cache) persist(key string, e *cacheEntry) {
+	if c.Dir == "" {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.Dir, 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(key), data, 0o600)
+}
+
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func cacheKey(account, endpoint string) string {
+	return fmt.Sprintf("%s %s", account, endpoint)
+}
+
+// resourceOf returns the first path segment of an endpoint: "/invoices/12/payments.json"
+// and "/invoices.json?page=2" both belong to "invoices".
+func resourceOf(endpoint string) string {
+	endpoint, _, _ = strings.Cut(endpoint, "?")
+	segment, _, _ := strings.Cut(strings.TrimPrefix(endpoint, "/"), "/")
+	return strings.TrimSuffix(segment, ".json")
+}
@@ -0,0 +1,186 @@
+package fakturoid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// cacheTestClient returns a client with cache talking to a test server that answers
+// every GET with its path and the ETag "v1", honouring If-None-Match. It counts the API
+// requests per method and path. onGet, when set, runs before a GET is answered.
+func cacheTestClient(t *testing.T, cache *Cache, onGet func(endpoint string)) (*Client, map[string]int) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/api/v3/oauth/token" {
+			w.Write([]byte(`{"access_token":"token","expires_in":7200}`))
+			return
+		}
+		endpoint := strings.TrimPrefix(req.URL.Path, "/api/v3/accounts/test")
+		mu.Lock()
+		requests[req.Method+" "+endpoint]++
+		mu.Unlock()
+		if req.Method != "GET" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if onGet != nil {
+			onGet(endpoint)
+		}
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"path":"` + endpoint + `"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	target, _ := url.Parse(srv.URL)
+	c := NewClient("id", "secret", "test")
+	c.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})})
+	c.SetCache(cache)
+	return c, requests
+}
+
+func get(t *testing.T, c *Client, endpoint string) {
+	t.Helper()
+	var result struct{ Path string }
+	if err := c.do("GET", endpoint, nil, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Path != endpoint {
+		t.Fatalf("GET %s returned %q", endpoint, result.Path)
+	}
+}
+
+func TestCacheInvalidation(t *testing.T) {
+	c, requests := cacheTestClient(t, NewCache(time.Hour, ""), nil)
+	endpoints := []string{"/invoices.json", "/inventory_items.json", "/inventory_moves.json", "/subjects/1.json"}
+	fetch := func() {
+		for _, e := range endpoints {
+			get(t, c, e)
+		}
+	}
+
+	fetch()
+	fetch()
+	for _, e := range endpoints {
+		if n := requests["GET "+e]; n != 1 {
+			t.Errorf("GET %s sent %d times, want 1", e, n)
+		}
+	}
+
+	// Issuing stock on an invoice changes the inventory.
+	if err := c.do("PATCH", "/invoices/1.json", map[string]any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	fetch()
+	want := map[string]int{"/invoices.json": 2, "/inventory_items.json": 2, "/inventory_moves.json": 2, "/subjects/1.json": 1}
+	for e, n := range want {
+		if got := requests["GET "+e]; got != n {
+			t.Errorf("after invoice update: GET %s sent %d times, want %d", e, got, n)
+		}
+	}
+
+	// An item's stock level is kept as moves.
+	if err := c.do("PATCH", "/inventory_items/1.json", map[string]any{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	fetch()
+	want = map[string]int{"/invoices.json": 2, "/inventory_items.json": 3, "/inventory_moves.json": 3, "/subjects/1.json": 1}
+	for e, n := range want {
+		if got := requests["GET "+e]; got != n {
+			t.Errorf("after item update: GET %s sent %d times, want %d", e, got, n)
+		}
+	}
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	cache := NewCache(time.Hour, "")
+	c, requests := cacheTestClient(t, cache, nil)
+	get(t, c, "/account.json")
+
+	cache.mu.Lock()
+	for _, e := range cache.entries {
+		e.Expires = time.Now().Add(-time.Second)
+	}
+	cache.mu.Unlock()
+
+	get(t, c, "/account.json")
+	get(t, c, "/account.json")
+	if n := requests["GET /account.json"]; n != 2 {
+		t.Errorf("GET sent %d times, want 2 (fetch and revalidation)", n)
+	}
+	if s := cache.Stats(); s.Misses != 1 || s.Revalidated != 1 || s.Hits != 1 || s.Fresh != 1 {
+		t.Errorf("stats = %+v, want 1 miss, 1 revalidation, 1 hit", s)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(time.Hour, dir)
+	cache.MaxEntries = 2
+	c, requests := cacheTestClient(t, cache, nil)
+	for _, e := range []string{"/subjects/1.json", "/subjects/2.json", "/subjects/3.json"} {
+		get(t, c, e)
+		time.Sleep(time.Millisecond)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if s := cache.Stats(); s.Entries != 2 || s.Evictions != 1 || len(files) != 2 {
+		t.Fatalf("%d entries, %d evictions, %d files; want 2, 1, 2", s.Entries, s.Evictions, len(files))
+	}
+	get(t, c, "/subjects/3.json")
+	get(t, c, "/subjects/1.json")
+	if requests["GET /subjects/3.json"] != 1 || requests["GET /subjects/1.json"] != 2 {
+		t.Errorf("requests = %v, want the oldest entry evicted", requests)
+	}
+
+	// Evicted responses are gone from the directory as well.
+	if s := NewCache(time.Hour, dir).Stats(); s.Entries != 2 || s.Resources["subjects"] != 2 {
+		t.Errorf("reloaded %d entries, want 2", s.Entries)
+	}
+}
+
+func TestCacheRevalidationAfterInvalidation(t *testing.T) {
+	cache := NewCache(time.Hour, "")
+	var c *Client
+	invalidate := false
+	c, requests := cacheTestClient(t, cache, func(endpoint string) {
+		// A write made while the conditional request is in flight.
+		if invalidate {
+			invalidate = false
+			c.InvalidateCache("subjects")
+		}
+	})
+	get(t, c, "/subjects/1.json")
+
+	cache.mu.Lock()
+	for _, e := range cache.entries {
+		e.Expires = time.Now().Add(-time.Second)
+	}
+	cache.mu.Unlock()
+
+	invalidate = true
+	get(t, c, "/subjects/1.json")
+	if n := requests["GET /subjects/1.json"]; n != 3 {
+		t.Errorf("GET sent %d times, want 3 (fetch, revalidation, full fetch after the invalidation)", n)
+	}
+	if s := cache.Stats(); s.Revalidated != 0 || s.Misses != 2 || s.Entries != 1 {
+		t.Errorf("stats = %+v, want 2 misses and the refetched entry", s)
+	}
+}
@@ -3,6 +3,7 @@ package fakturoid
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +15,9 @@ const baseURL = "https://app.fakturoid.cz/api/v3"
 const tokenURL = "https://app.fakturoid.cz/api/v3/oauth/token"
 const userAgent = "fakturoid-mcp (github.com/tedyno/fakturoid-mcp)"
 
+// ErrRateLimited is returned when Fakturoid rejects a request with 429 Too Many Requests.
+var ErrRateLimited = errors.New("fakturoid rate limit exceeded, try again later")
+
 type Client struct {
 	clientID     string
 	clientSecret string
@@ -23,6 +27,8 @@ type Client struct {
 	mu          sync.Mutex
 	accessToken string
 	tokenExpiry time.Time
+
+	cache *Cache
 }
 
 func NewClient(clientID, clientSecret, slug string) *Client {
@@ -36,6 +42,12 @@ func NewClient(clientID, clientSecret, slug string) *Client {
 	}
 }
 
+// SetHTTPClient replaces the HTTP client used for API and token requests, e.g. to route
+// them through a proxy or a test server.
+func (c *Client) SetHTTPClient(hc *http.Client) {
+	c.httpClient = hc
+}
+
 type tokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
@@ -87,10 +99,6 @@ func (c *Client) authenticate() error {
 }
 
 func (c *Client) do(method, endpoint string, body any, result any) error {
-	if err := c.authenticate(); err != nil {
-		return err
-	}
-
 	var bodyReader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -100,10 +108,44 @@ func (c *Client) do(method, endpoint string, body any, result any) error {
 		bodyReader = bytes.NewReader(data)
 	}
 
+	respBody, _, err := c.send(method, endpoint, bodyReader, "application/json")
+	if err != nil {
+		return err
+	}
+
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// download fetches a binary document such as a PDF. The status code lets callers tell
+// a document that is not ready yet (204) from an empty one.
+func (c *Client) download(endpoint string) ([]byte, int, error) {
+	return c.send("GET", endpoint, nil, "*/*")
+}
+
+func (c *Client) send(method, endpoint string, body io.Reader, accept string) ([]byte, int, error) {
+	cacheable := c.cache != nil && method == "GET" && accept == "application/json"
+	var cached *cacheEntry
+	if cacheable {
+		var fresh bool
+		if cached, fresh = c.cache.lookup(c.slug, endpoint); fresh {
+			return cached.Body, 200, nil
+		}
+	}
+
+	if err := c.authenticate(); err != nil {
+		return nil, 0, err
+	}
+
 	fullURL := fmt.Sprintf("%s/accounts/%s%s", baseURL, c.slug, endpoint)
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+	req, err := http.NewRequest(method, fullURL, body)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, 0, fmt.Errorf("create request: %w", err)
 	}
 
 	c.mu.Lock()
@@ -112,32 +154,45 @@ func (c *Client) do(method, endpoint string, body any, result any) error {
 
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
 	req.Header.Set("User-Agent", userAgent)
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, 0, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read response: %w", err)
+		return nil, 0, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if body, ok := c.cache.revalidated(c.slug, endpoint, cached.ETag); ok {
+			return body, 200, nil
+		}
+		// A write dropped the entry while the request was in flight; fetch it in full.
+		return c.send(method, endpoint, nil, accept)
 	}
 
 	if resp.StatusCode == 429 {
-		return fmt.Errorf("fakturoid rate limit exceeded, try again later")
+		return nil, resp.StatusCode, ErrRateLimited
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("fakturoid API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, resp.StatusCode, fmt.Errorf("fakturoid API error (%d): %s", resp.StatusCode, string(respBody))
 	}
 
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("unmarshal response: %w", err)
+	if c.cache != nil {
+		if cacheable {
+			c.cache.store(c.slug, endpoint, respBody, resp.Header.Get("ETag"))
+		} else if method != "GET" {
+			c.cache.invalidate(c.slug, resourceOf(endpoint))
 		}
 	}
-
-	return nil
+	return respBody, resp.StatusCode, nil
 }
@@ -20,3 +20,21 @@ func (c *Client) GetExpense(id int) (*Expense, error) {
 	err := c.do("GET", fmt.Sprintf("/expenses/%d.json", id), nil, &result)
 	return &result, err
 }
+
+func (c *Client) CreateExpense(req CreateExpenseRequest) (*Expense, error) {
+	var result Expense
+	err := c.do("POST", "/expenses.json", req, &result)
+	return &result, err
+}
+
+func (c *Client) UpdateExpense(id int, req UpdateExpenseRequest) (*Expense, error) {
+	var result Expense
+	err := c.do("PATCH", fmt.Sprintf("/expenses/%d.json", id), req, &result)
+	return &result, err
+}
+
+func (c *Client) CreateExpensePayment(expenseID int, req CreateExpensePaymentRequest) (*ExpensePayment, error) {
+	var result ExpensePayment
+	err := c.do("POST", fmt.Sprintf("/expenses/%d/payments.json", expenseID), req, &result)
+	return &result, err
+}
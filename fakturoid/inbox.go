@@ -0,0 +1,34 @@
+package fakturoid
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+)
+
+// DataURL encodes file content as a base64 data URI, the format the API expects for uploads.
+func DataURL(mimeType string, data []byte) string {
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+}
+
+func (c *Client) GetInboxFiles(page int) ([]InboxFile, error) {
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	var result []InboxFile
+	err := c.do("GET", fmt.Sprintf("/inbox_files.json?%s", params.Encode()), nil, &result)
+	return result, err
+}
+
+func (c *Client) CreateInboxFile(req CreateInboxFileRequest) (*InboxFile, error) {
+	var result InboxFile
+	err := c.do("POST", "/inbox_files.json", req, &result)
+	return &result, err
+}
+
+func (c *Client) SendInboxFileToOCR(id int) error {
+	return c.do("POST", fmt.Sprintf("/inbox_files/%d/send_to_ocr.json", id), nil, nil)
+}
+
+func (c *Client) DeleteInboxFile(id int) error {
+	return c.do("DELETE", fmt.Sprintf("/inbox_files/%d.json", id), nil, nil)
+}
@@ -0,0 +1,78 @@
+package fakturoid
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// GetInventoryItems lists inventory items. filter is "" for active items, "archived" or "low_quantity".
+func (c *Client) GetInventoryItems(page int, filter string, params url.Values) ([]InventoryItem, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("page", fmt.Sprintf("%d", page))
+	endpoint := "/inventory_items.json"
+	if filter != "" {
+		endpoint = fmt.Sprintf("/inventory_items/%s.json", filter)
+	}
+	var result []InventoryItem
+	err := c.do("GET", fmt.Sprintf("%s?%s", endpoint, params.Encode()), nil, &result)
+	return result, err
+}
+
+func (c *Client) GetInventoryItem(id int) (*InventoryItem, error) {
+	var result InventoryItem
+	err := c.do("GET", fmt.Sprintf("/inventory_items/%d.json", id), nil, &result)
+	return &result, err
+}
+
+func (c *Client) SearchInventoryItems(query string, page int) ([]InventoryItem, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("page", fmt.Sprintf("%d", page))
+	var result []InventoryItem
+	err := c.do("GET", fmt.Sprintf("/inventory_items/search.json?%s", params.Encode()), nil, &result)
+	return result, err
+}
+
+func (c *Client) CreateInventoryItem(req CreateInventoryItemRequest) (*InventoryItem, error) {
+	var result InventoryItem
+	err := c.do("POST", "/inventory_items.json", req, &result)
+	return &result, err
+}
+
+func (c *Client) UpdateInventoryItem(id int, req UpdateInventoryItemRequest) (*InventoryItem, error) {
+	var result InventoryItem
+	err := c.do("PATCH", fmt.Sprintf("/inventory_items/%d.json", id), req, &result)
+	return &result, err
+}
+
+func (c *Client) ArchiveInventoryItem(id int) (*InventoryItem, error) {
+	var result InventoryItem
+	err := c.do("POST", fmt.Sprintf("/inventory_items/%d/archive.json", id), nil, &result)
+	return &result, err
+}
+
+func (c *Client) UnarchiveInventoryItem(id int) (*InventoryItem, error) {
+	var result InventoryItem
+	err := c.do("POST", fmt.Sprintf("/inventory_items/%d/unarchive.json", id), nil, &result)
+	return &result, err
+}
+
+// GetInventoryMoves lists stock moves, optionally for a single item (itemID 0 means all items).
+func (c *Client) GetInventoryMoves(page int, itemID int) ([]InventoryMove, error) {
+	params := url.Values{}
+	params.Set("page", fmt.Sprintf("%d", page))
+	if itemID != 0 {
+		params.Set("inventory_item_id", fmt.Sprintf("%d", itemID))
+	}
+	var result []InventoryMove
+	err := c.do("GET", fmt.Sprintf("/inventory_moves.json?%s", params.Encode()), nil, &result)
+	return result, err
+}
+
+func (c *Client) CreateInventoryMove(itemID int, req CreateInventoryMoveRequest) (*InventoryMove, error) {
+	var result InventoryMove
+	err := c.do("POST", fmt.Sprintf("/inventory_items/%d/inventory_moves.json", itemID), req, &result)
+	return &result, err
+}
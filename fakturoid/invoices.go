@@ -46,12 +46,45 @@ func (c *Client) DeleteInvoice(id int) error {
 	return c.do("DELETE", fmt.Sprintf("/invoices/%d.json", id), nil, nil)
 }
 
+// Invoice events accepted by FireInvoiceEvent.
+const (
+	InvoiceEventMarkAsSent = "mark_as_sent"
+	InvoiceEventLock       = "lock"
+	InvoiceEventUnlock     = "unlock"
+	InvoiceEventCancel     = "cancel"
+	InvoiceEventUndoCancel = "undo_cancel"
+)
+
+// FireInvoiceEvent changes an invoice's state, e.g. marks it as sent or locks it.
+func (c *Client) FireInvoiceEvent(id int, event string) error {
+	return c.do("POST", fmt.Sprintf("/invoices/%d/fire.json?event=%s", id, url.QueryEscape(event)), nil, nil)
+}
+
 func (c *Client) SendInvoice(invoiceID int, req SendInvoiceRequest) error {
 	return c.do("POST", fmt.Sprintf("/invoices/%d/message.json", invoiceID), req, nil)
 }
 
+// DownloadInvoicePDF returns the invoice PDF. Fakturoid renders PDFs in the background,
+// so a freshly created invoice may not have one yet.
+func (c *Client) DownloadInvoicePDF(id int) ([]byte, error) {
+	data, status, err := c.download(fmt.Sprintf("/invoices/%d/download.pdf", id))
+	if err != nil {
+		return nil, err
+	}
+	if status == 204 || len(data) == 0 {
+		return nil, fmt.Errorf("PDF of invoice %d is not generated yet, try again shortly", id)
+	}
+	return data, nil
+}
+
 func (c *Client) GetInvoicePayments(invoiceID int) ([]InvoicePayment, error) {
 	var result []InvoicePayment
 	err := c.do("GET", fmt.Sprintf("/invoices/%d/payments.json", invoiceID), nil, &result)
 	return result, err
 }
+
+func (c *Client) CreateInvoicePayment(invoiceID int, req CreateInvoicePaymentRequest) (*InvoicePayment, error) {
+	var result InvoicePayment
+	err := c.do("POST", fmt.Sprintf("/invoices/%d/payments.json", invoiceID), req, &result)
+	return &result, err
+}
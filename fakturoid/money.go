@@ -0,0 +1,335 @@
+package fakturoid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Amount is an exact decimal number used for prices, quantities, VAT rates and totals.
+// The zero value is 0. Amounts are immutable; arithmetic returns new values.
+type Amount struct {
+	r *big.Rat
+}
+
+// RoundingMode selects how Round resolves digits beyond the requested precision.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds half away from zero (Fakturoid's "mathematical" rounding).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds half to the nearest even digit (banker's rounding).
+	RoundHalfEven
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundDown rounds towards zero (truncation).
+	RoundDown
+)
+
+// maxDecimals limits the digits printed for amounts that have no finite decimal form (e.g. 1/3).
+const maxDecimals = 12
+
+var ten = big.NewInt(10)
+
+func NewAmount(v int64) Amount {
+	return Amount{r: new(big.Rat).SetInt64(v)}
+}
+
+// ParseAmount parses a decimal string such as "1210", "-0.5" or "1210.0".
+// An empty string parses as zero, matching how the API reports missing amounts.
+func ParseAmount(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Amount{}, nil
+	}
+	if strings.ContainsAny(s, "/eE") {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+	return Amount{r: r}, nil
+}
+
+// MustParseAmount is like ParseAmount but panics on invalid input. Intended for constants.
+func MustParseAmount(s string) Amount {
+	a, err := ParseAmount(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+func (a Amount) rat() *big.Rat {
+	if a.r == nil {
+		return new(big.Rat)
+	}
+	return a.r
+}
+
+func (a Amount) Add(b Amount) Amount {
+	return Amount{r: new(big.Rat).Add(a.rat(), b.rat())}
+}
+
+func (a Amount) Sub(b Amount) Amount {
+	return Amount{r: new(big.Rat).Sub(a.rat(), b.rat())}
+}
+
+func (a Amount) Mul(b Amount) Amount {
+	return Amount{r: new(big.Rat).Mul(a.rat(), b.rat())}
+}
+
+func (a Amount) Div(b Amount) (Amount, error) {
+	if b.IsZero() {
+		return Amount{}, fmt.Errorf("division by zero")
+	}
+	return Amount{r: new(big.Rat).Quo(a.rat(), b.rat())}, nil
+}
+
+// Percent returns rate percent of a, e.g. NewAmount(1000).Percent(NewAmount(21)) == 210.
+func (a Amount) Percent(rate Amount) Amount {
+	return Amount{r: new(big.Rat).Quo(new(big.Rat).Mul(a.rat(), rate.rat()), big.NewRat(100, 1))}
+}
+
+func (a Amount) Neg() Amount {
+	return Amount{r: new(big.Rat).Neg(a.rat())}
+}
+
+func (a Amount) Abs() Amount {
+	return Amount{r: new(big.Rat).Abs(a.rat())}
+}
+
+func (a Amount) Cmp(b Amount) int {
+	return a.rat().Cmp(b.rat())
+}
+
+func (a Amount) Sign() int {
+	return a.rat().Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// Float64 returns the nearest float64. Use only for display or charting, never for further arithmetic.
+func (a Amount) Float64() float64 {
+	f, _ := a.rat().Float64()
+	return f
+}
+
+// Round rounds a to the given number of decimal places.
+func (a Amount) Round(places int, mode RoundingMode) Amount {
+	scale := new(big.Int).Exp(ten, big.NewInt(int64(places)), nil)
+	scaled := new(big.Rat).Mul(a.rat(), new(big.Rat).SetInt(scale))
+
+	q, rem := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if rem.Sign() != 0 {
+		// Compare 2*|rem| with the denominator to find out which side of half we are on.
+		twice := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+		half := twice.Cmp(scaled.Denom())
+
+		away := false
+		switch mode {
+		case RoundUp:
+			away = true
+		case RoundDown:
+			away = false
+		case RoundHalfUp:
+			away = half >= 0
+		case RoundHalfEven:
+			away = half > 0 || (half == 0 && q.Bit(0) == 1)
+		}
+		if away {
+			if scaled.Sign() < 0 {
+				q.Sub(q, big.NewInt(1))
+			} else {
+				q.Add(q, big.NewInt(1))
+			}
+		}
+	}
+
+	return Amount{r: new(big.Rat).SetFrac(q, scale)}
+}
+
+// decimals returns the number of decimal places needed to print a exactly,
+// or -1 if a has no finite decimal representation within maxDecimals.
+func (a Amount) decimals() int {
+	r := a.rat()
+	if r.IsInt() {
+		return 0
+	}
+	x := new(big.Rat).Set(r)
+	for i := 1; i <= maxDecimals; i++ {
+		x.Mul(x, new(big.Rat).SetInt(ten))
+		if x.IsInt() {
+			return i
+		}
+	}
+	return -1
+}
+
+// String formats a as a plain decimal with as many places as needed (at most maxDecimals).
+func (a Amount) String() string {
+	places := a.decimals()
+	if places < 0 {
+		return strings.TrimRight(strings.TrimRight(a.StringFixed(maxDecimals), "0"), ".")
+	}
+	return a.StringFixed(places)
+}
+
+// StringFixed formats a with exactly the given number of decimal places, rounding half up.
+func (a Amount) StringFixed(places int) string {
+	s := a.Round(places, RoundHalfUp).rat().FloatString(places)
+	if strings.Trim(s, "-0.") == "" {
+		s = strings.TrimPrefix(s, "-")
+	}
+	return s
+}
+
+// MarshalJSON encodes the amount as a decimal string, the same way the API returns amounts.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON accepts a JSON number, a decimal string or null.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*a = Amount{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseAmount(normalizeExponent(s))
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// normalizeExponent expands JSON numbers in exponent form (1e3) which ParseAmount rejects.
+func normalizeExponent(s string) string {
+	if !strings.ContainsAny(s, "eE") {
+		return s
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return s
+	}
+	return Amount{r: r}.String()
+}
+
+// --- Money ---
+
+// Money is an amount in a specific currency.
+type Money struct {
+	Amount   Amount `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func NewMoney(amount Amount, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+// CurrencyDecimals returns the number of minor-unit digits used for the currency.
+func CurrencyDecimals(currency string) int {
+	switch strings.ToUpper(currency) {
+	case "JPY", "KRW":
+		return 0
+	}
+	return 2
+}
+
+// TotalDecimals returns the precision Fakturoid rounds document totals to.
+// CZK totals are rounded to whole crowns; other currencies keep their minor units.
+func TotalDecimals(currency string) int {
+	if strings.EqualFold(currency, "CZK") {
+		return 0
+	}
+	return CurrencyDecimals(currency)
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if !m.sameCurrency(o) {
+		return Money{}, fmt.Errorf("currency mismatch: %s vs %s", m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount.Add(o.Amount), Currency: m.currency(o)}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.sameCurrency(o) {
+		return Money{}, fmt.Errorf("currency mismatch: %s vs %s", m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount.Sub(o.Amount), Currency: m.currency(o)}, nil
+}
+
+// Round rounds to the currency's minor units (haléře for CZK).
+func (m Money) Round(mode RoundingMode) Money {
+	return Money{Amount: m.Amount.Round(CurrencyDecimals(m.Currency), mode), Currency: m.Currency}
+}
+
+// RoundTotal rounds a document total the way Fakturoid does (whole crowns for CZK).
+func (m Money) RoundTotal(mode RoundingMode) Money {
+	return Money{Amount: m.Amount.Round(TotalDecimals(m.Currency), mode), Currency: m.Currency}
+}
+
+func (m Money) String() string {
+	s := m.Amount.StringFixed(CurrencyDecimals(m.Currency))
+	if m.Currency == "" {
+		return s
+	}
+	return s + " " + m.Currency
+}
+
+// sameCurrency treats an empty currency as compatible, so zero values can seed sums.
+func (m Money) sameCurrency(o Money) bool {
+	return m.Currency == "" || o.Currency == "" || strings.EqualFold(m.Currency, o.Currency)
+}
+
+func (m Money) currency(o Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+// --- Document helpers ---
+
+func (i Invoice) TotalMoney() Money {
+	return NewMoney(i.Total, i.Currency)
+}
+
+func (i Invoice) RemainingMoney() Money {
+	return NewMoney(i.RemainingAmount, i.Currency)
+}
+
+func (e Expense) TotalMoney() Money {
+	return NewMoney(e.Total, e.Currency)
+}
+
+func (p InvoicePayment) Money() Money {
+	return NewMoney(p.Amount, p.Currency)
+}
+
+// RemainingNative returns the unpaid amount in the account currency. The second result is
+// false when the invoice is in a foreign currency and carries no native amount or rate.
+func (i Invoice) RemainingNative(accountCurrency string) (Amount, bool) {
+	if i.Currency == "" || strings.EqualFold(i.Currency, accountCurrency) {
+		return i.RemainingAmount, true
+	}
+	if !i.RemainingNativeAmount.IsZero() || i.RemainingAmount.IsZero() {
+		return i.RemainingNativeAmount, true
+	}
+	if !i.ExchangeRate.IsZero() {
+		return i.RemainingAmount.Mul(i.ExchangeRate).Round(CurrencyDecimals(accountCurrency), RoundHalfUp), true
+	}
+	return Amount{}, false
+}
@@ -0,0 +1,173 @@
+package fakturoid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"1210", "1210", false},
+		{"-0.5", "-0.5", false},
+		{"1210.0", "1210", false},
+		{" 0.10 ", "0.1", false},
+		{"", "0", false},
+		{"1/3", "", true},
+		{"1e3", "", true},
+		{"abc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmount(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("ParseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAmountExactArithmetic(t *testing.T) {
+	// 0.1 + 0.2 is exactly 0.3, unlike with float64.
+	sum := MustParseAmount("0.1").Add(MustParseAmount("0.2"))
+	if sum.Cmp(MustParseAmount("0.3")) != 0 {
+		t.Errorf("0.1 + 0.2 = %s", sum)
+	}
+	if got := NewAmount(1000).Percent(NewAmount(21)); got.String() != "210" {
+		t.Errorf("21 %% of 1000 = %s", got)
+	}
+	third, err := NewAmount(1).Div(NewAmount(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := third.Mul(NewAmount(3)); got.Cmp(NewAmount(1)) != 0 {
+		t.Errorf("1/3 * 3 = %s", got)
+	}
+	if _, err := NewAmount(1).Div(Amount{}); err == nil {
+		t.Error("division by zero succeeded")
+	}
+}
+
+func TestAmountRound(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int
+		mode   RoundingMode
+		want   string
+	}{
+		{"2.345", 2, RoundHalfUp, "2.35"},
+		{"-2.345", 2, RoundHalfUp, "-2.35"},
+		{"2.345", 2, RoundHalfEven, "2.34"},
+		{"2.355", 2, RoundHalfEven, "2.36"},
+		{"2.341", 2, RoundUp, "2.35"},
+		{"-2.341", 2, RoundUp, "-2.35"},
+		{"2.349", 2, RoundDown, "2.34"},
+		{"1210.50", 0, RoundHalfUp, "1211"},
+		{"1210.49", 0, RoundHalfUp, "1210"},
+		{"0.005", 2, RoundHalfUp, "0.01"},
+	}
+	for _, tt := range tests {
+		got := MustParseAmount(tt.in).Round(tt.places, tt.mode)
+		if got.StringFixed(tt.places) != tt.want {
+			t.Errorf("Round(%s, %d, %d) = %s, want %s", tt.in, tt.places, tt.mode, got.StringFixed(tt.places), tt.want)
+		}
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	third, _ := NewAmount(1).Div(NewAmount(3))
+	tests := []struct {
+		a    Amount
+		want string
+	}{
+		{Amount{}, "0"},
+		{MustParseAmount("12.50"), "12.5"},
+		{third, "0.333333333333"},
+		{MustParseAmount("-0.001"), "-0.001"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+	if got := MustParseAmount("-0.001").StringFixed(2); got != "0.00" {
+		t.Errorf("StringFixed of a tiny negative = %s, want 0.00", got)
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	var v struct {
+		A Amount `json:"a"`
+		B Amount `json:"b"`
+		C Amount `json:"c"`
+		D Amount `json:"d"`
+	}
+	if err := json.Unmarshal([]byte(`{"a": 12.5, "b": "1210.0", "c": null, "d": 1e3}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A.String() != "12.5" || v.B.String() != "1210" || !v.C.IsZero() || v.D.String() != "1000" {
+		t.Errorf("unmarshal = %s %s %s %s", v.A, v.B, v.C, v.D)
+	}
+	data, err := json.Marshal(v.A)
+	if err != nil || string(data) != `"12.5"` {
+		t.Errorf("marshal = %s, %v", data, err)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		currency   string
+		amount     string
+		round      string
+		roundTotal string
+	}{
+		// CZK documents are rounded to whole crowns, line amounts to haléře.
+		{"CZK", "1210.49", "1210.49 CZK", "1210.00 CZK"},
+		{"CZK", "1210.50", "1210.50 CZK", "1211.00 CZK"},
+		{"czk", "99.995", "100.00 CZK", "100.00 CZK"},
+		{"EUR", "10.005", "10.01 EUR", "10.01 EUR"},
+		{"JPY", "1000.5", "1001 JPY", "1001 JPY"},
+	}
+	for _, tt := range tests {
+		m := NewMoney(MustParseAmount(tt.amount), tt.currency)
+		if got := m.Round(RoundHalfUp).String(); got != tt.round {
+			t.Errorf("%s %s Round = %s, want %s", tt.amount, tt.currency, got, tt.round)
+		}
+		if got := m.RoundTotal(RoundHalfUp).String(); got != tt.roundTotal {
+			t.Errorf("%s %s RoundTotal = %s, want %s", tt.amount, tt.currency, got, tt.roundTotal)
+		}
+	}
+
+	if _, err := NewMoney(NewAmount(1), "CZK").Add(NewMoney(NewAmount(1), "EUR")); err == nil {
+		t.Error("adding CZK and EUR succeeded")
+	}
+	sum, err := (Money{}).Add(NewMoney(NewAmount(5), "EUR"))
+	if err != nil || sum.String() != "5.00 EUR" {
+		t.Errorf("zero + 5 EUR = %s, %v", sum, err)
+	}
+}
+
+func TestRemainingNative(t *testing.T) {
+	tests := []struct {
+		name   string
+		inv    Invoice
+		want   string
+		wantOK bool
+	}{
+		{"account currency", Invoice{Currency: "CZK", RemainingAmount: NewAmount(100)}, "100", true},
+		{"native amount", Invoice{Currency: "EUR", RemainingAmount: NewAmount(10), RemainingNativeAmount: NewAmount(250)}, "250", true},
+		{"rate", Invoice{Currency: "EUR", RemainingAmount: NewAmount(10), ExchangeRate: MustParseAmount("25.125")}, "251.25", true},
+		{"no rate", Invoice{Currency: "EUR", RemainingAmount: NewAmount(10)}, "0", false},
+	}
+	for _, tt := range tests {
+		got, ok := tt.inv.RemainingNative("CZK")
+		if ok != tt.wantOK || got.String() != tt.want {
+			t.Errorf("%s: RemainingNative = %s, %v; want %s, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
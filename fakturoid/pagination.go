@@ -0,0 +1,26 @@
+package fakturoid
+
+import "fmt"
+
+// PageSize is the number of records the API returns per page.
+const PageSize = 40
+
+// maxPages stops AllPages from looping forever if the API keeps returning full pages.
+const maxPages = 500
+
+// AllPages calls fetch for page 1, 2, ... until a page comes back shorter than PageSize.
+// It fails rather than return a partial list when there are more than maxPages pages.
+func AllPages[T any](fetch func(page int) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := 1; page <= maxPages; page++ {
+		items, err := fetch(page)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+		if len(items) < PageSize {
+			return all, nil
+		}
+	}
+	return all, fmt.Errorf("more than %d pages of %d records; narrow the query", maxPages, PageSize)
+}
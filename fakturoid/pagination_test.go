@@ -0,0 +1,45 @@
+package fakturoid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAllPages(t *testing.T) {
+	pages := func(sizes ...int) func(int) ([]int, error) {
+		return func(page int) ([]int, error) {
+			if page > len(sizes) {
+				t.Fatalf("fetched page %d of %d", page, len(sizes))
+			}
+			return make([]int, sizes[page-1]), nil
+		}
+	}
+
+	all, err := AllPages(pages(PageSize, PageSize, 3))
+	if err != nil || len(all) != 2*PageSize+3 {
+		t.Errorf("got %d items, %v", len(all), err)
+	}
+	all, err = AllPages(pages(PageSize, 0))
+	if err != nil || len(all) != PageSize {
+		t.Errorf("exact page: got %d items, %v", len(all), err)
+	}
+
+	boom := errors.New("boom")
+	_, err = AllPages(func(page int) ([]int, error) {
+		if page == 2 {
+			return nil, boom
+		}
+		return make([]int, PageSize), nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("error = %v, want boom", err)
+	}
+
+	all, err = AllPages(func(page int) ([]int, error) { return make([]int, PageSize), nil })
+	if err == nil {
+		t.Error("no error when the page cap was hit")
+	}
+	if len(all) != maxPages*PageSize {
+		t.Errorf("got %d items at the cap", len(all))
+	}
+}
@@ -0,0 +1,181 @@
+package fakturoid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VAT price modes accepted by the API in vat_price_mode.
+const (
+	VATPriceModeWithoutVAT = "without_vat"
+	VATPriceModeWithVAT    = "from_total_with_vat"
+)
+
+// vatRates lists the VAT rates currently valid in countries we know about.
+var vatRates = map[string][]string{
+	"CZ": {"0", "12", "21"},
+	"SK": {"0", "5", "19", "23"},
+	"AT": {"0", "10", "13", "20"},
+	"DE": {"0", "7", "19"},
+	"PL": {"0", "5", "8", "23"},
+	"HU": {"0", "5", "18", "27"},
+}
+
+// KnownVATRate reports whether rate is a valid VAT rate in country.
+// The second result is false when there is no rate table for the country.
+func KnownVATRate(country string, rate Amount) (known, ok bool) {
+	rates, ok := vatRates[strings.ToUpper(country)]
+	if !ok {
+		return false, false
+	}
+	for _, r := range rates {
+		if MustParseAmount(r).Cmp(rate) == 0 {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+type PreviewOptions struct {
+	Currency     string
+	VATPriceMode string
+	// Country is the seller's country, used to check VAT rates.
+	Country string
+	// VATPayer is false for accounts that must not charge VAT.
+	VATPayer bool
+}
+
+type LinePreview struct {
+	Name      string `json:"name"`
+	Quantity  Amount `json:"quantity"`
+	UnitPrice Amount `json:"unit_price"`
+	VATRate   Amount `json:"vat_rate"`
+	Total     Amount `json:"total"`
+}
+
+type VATRateSummary struct {
+	Rate  Amount `json:"rate"`
+	Base  Amount `json:"base"`
+	VAT   Amount `json:"vat"`
+	Total Amount `json:"total"`
+}
+
+type InvoicePreview struct {
+	Currency     string           `json:"currency"`
+	VATPriceMode string           `json:"vat_price_mode"`
+	Lines        []LinePreview    `json:"lines"`
+	VATRates     []VATRateSummary `json:"vat_rates"`
+	Subtotal     Amount           `json:"subtotal"`
+	VAT          Amount           `json:"vat"`
+	TotalBefore  Amount           `json:"total_before_rounding"`
+	Rounding     Amount           `json:"rounding"`
+	Total        Amount           `json:"total"`
+	Warnings     []string         `json:"warnings,omitempty"`
+}
+
+// PreviewInvoice computes the totals Fakturoid will produce for the given lines.
+// VAT is calculated per rate from the rounded rate base, and the grand total is
+// rounded to whole crowns for CZK documents.
+func PreviewInvoice(lines []InvoiceLine, opts PreviewOptions) InvoicePreview {
+	mode := opts.VATPriceMode
+	if mode == "" {
+		mode = VATPriceModeWithoutVAT
+	}
+	p := InvoicePreview{
+		Currency:     strings.ToUpper(opts.Currency),
+		VATPriceMode: mode,
+	}
+	if mode != VATPriceModeWithoutVAT && mode != VATPriceModeWithVAT {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("unknown vat_price_mode %q, calculated as %s", mode, VATPriceModeWithoutVAT))
+		mode = VATPriceModeWithoutVAT
+	}
+
+	decimals := CurrencyDecimals(p.Currency)
+	sums := map[string]Amount{}
+	rates := map[string]Amount{}
+
+	for i, l := range lines {
+		total := l.Quantity.Mul(l.UnitPrice).Round(decimals, RoundHalfUp)
+		p.Lines = append(p.Lines, LinePreview{
+			Name:      l.Name,
+			Quantity:  l.Quantity,
+			UnitPrice: l.UnitPrice,
+			VATRate:   l.VATRate,
+			Total:     total,
+		})
+
+		key := l.VATRate.String()
+		sums[key] = sums[key].Add(total)
+		rates[key] = l.VATRate
+
+		p.Warnings = append(p.Warnings, lineWarnings(i, l, opts)...)
+	}
+
+	keys := make([]string, 0, len(rates))
+	for k := range rates {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return rates[keys[i]].Cmp(rates[keys[j]]) < 0 })
+
+	for _, k := range keys {
+		rate, sum := rates[k], sums[k]
+		s := VATRateSummary{Rate: rate}
+		if mode == VATPriceModeWithVAT {
+			// VAT = total * rate / (100 + rate)
+			divisor := NewAmount(100).Add(rate)
+			vat, err := sum.Mul(rate).Div(divisor)
+			if err != nil {
+				vat = Amount{}
+			}
+			s.Total = sum
+			s.VAT = vat.Round(decimals, RoundHalfUp)
+			s.Base = s.Total.Sub(s.VAT)
+		} else {
+			s.Base = sum
+			s.VAT = sum.Percent(rate).Round(decimals, RoundHalfUp)
+			s.Total = s.Base.Add(s.VAT)
+		}
+		p.VATRates = append(p.VATRates, s)
+		p.Subtotal = p.Subtotal.Add(s.Base)
+		p.VAT = p.VAT.Add(s.VAT)
+		p.TotalBefore = p.TotalBefore.Add(s.Total)
+	}
+
+	p.Total = p.TotalBefore.Round(TotalDecimals(p.Currency), RoundHalfUp)
+	p.Rounding = p.Total.Sub(p.TotalBefore)
+
+	if len(lines) > 0 && p.Total.IsZero() {
+		p.Warnings = append(p.Warnings, "invoice total is zero")
+	}
+	return p
+}
+
+func lineWarnings(i int, l InvoiceLine, opts PreviewOptions) []string {
+	var warnings []string
+	label := fmt.Sprintf("line %d (%q)", i+1, l.Name)
+
+	if strings.TrimSpace(l.Name) == "" {
+		warnings = append(warnings, fmt.Sprintf("line %d has no name", i+1))
+	}
+	if l.Quantity.Sign() <= 0 {
+		warnings = append(warnings, fmt.Sprintf("%s: quantity is %s", label, l.Quantity))
+	}
+	if l.UnitPrice.IsZero() {
+		warnings = append(warnings, fmt.Sprintf("%s: unit price is zero", label))
+	}
+	if l.VATRate.Sign() < 0 {
+		warnings = append(warnings, fmt.Sprintf("%s: negative VAT rate %s", label, l.VATRate))
+	}
+
+	if !opts.VATPayer {
+		if !l.VATRate.IsZero() {
+			warnings = append(warnings, fmt.Sprintf("%s: VAT rate %s%% on an account that is not a VAT payer", label, l.VATRate))
+		}
+		return warnings
+	}
+	if known, ok := KnownVATRate(opts.Country, l.VATRate); ok && !known {
+		warnings = append(warnings, fmt.Sprintf("%s: %s%% is not a current VAT rate in %s", label, l.VATRate, strings.ToUpper(opts.Country)))
+	}
+	return warnings
+}
@@ -1,53 +1,103 @@
 package fakturoid
 
-import "encoding/json"
-
 // --- Invoice ---
 
 type Invoice struct {
-	ID              int          `json:"id"`
-	Number          string       `json:"number"`
-	SubjectID       int          `json:"subject_id"`
-	Status          string       `json:"status"`
-	DocumentType    string       `json:"document_type"`
-	IssuedOn        string       `json:"issued_on"`
-	TaxableFulfillmentDue string `json:"taxable_fulfillment_due"`
-	DueOn           string       `json:"due_on"`
-	PaidOn          string       `json:"paid_on,omitempty"`
-	Note            string       `json:"note,omitempty"`
-	FootNote        string       `json:"footer_note,omitempty"`
-	Currency        string       `json:"currency"`
-	NativeTotal     string       `json:"native_total"`
-	Total           string       `json:"total"`
-	RemainingAmount string       `json:"remaining_amount"`
-	Lines           []InvoiceLine `json:"lines,omitempty"`
-	SubjectName     string       `json:"subject_name,omitempty"`
+	ID                    int           `json:"id"`
+	Number                string        `json:"number"`
+	SubjectID             int           `json:"subject_id"`
+	Status                string        `json:"status"`
+	DocumentType          string        `json:"document_type"`
+	IssuedOn              string        `json:"issued_on"`
+	TaxableFulfillmentDue string        `json:"taxable_fulfillment_due"`
+	DueOn                 string        `json:"due_on"`
+	PaidOn                string        `json:"paid_on,omitempty"`
+	SentAt                string        `json:"sent_at,omitempty"`
+	LockedAt              string        `json:"locked_at,omitempty"`
+	CancelledAt           string        `json:"cancelled_at,omitempty"`
+	Note                  string        `json:"note,omitempty"`
+	FootNote              string        `json:"footer_note,omitempty"`
+	Currency              string        `json:"currency"`
+	VATPriceMode          string        `json:"vat_price_mode,omitempty"`
+	Subtotal              Amount        `json:"subtotal"`
+	NativeSubtotal        Amount        `json:"native_subtotal"`
+	NativeTotal           Amount        `json:"native_total"`
+	Total                 Amount        `json:"total"`
+	RemainingAmount       Amount        `json:"remaining_amount"`
+	RemainingNativeAmount Amount        `json:"remaining_native_amount"`
+	ExchangeRate          Amount        `json:"exchange_rate"`
+	Lines                 []InvoiceLine `json:"lines,omitempty"`
+	SubjectName           string        `json:"subject_name,omitempty"`
+	ClientName            string        `json:"client_name,omitempty"`
+	ClientVATNo           string        `json:"client_vat_no,omitempty"`
+	ClientRegistrationNo  string        `json:"client_registration_no,omitempty"`
+	ClientStreet          string        `json:"client_street,omitempty"`
+	ClientCity            string        `json:"client_city,omitempty"`
+	ClientZip             string        `json:"client_zip,omitempty"`
+	ClientCountry         string        `json:"client_country,omitempty"`
+	YourName              string        `json:"your_name,omitempty"`
+	YourStreet            string        `json:"your_street,omitempty"`
+	YourCity              string        `json:"your_city,omitempty"`
+	YourZip               string        `json:"your_zip,omitempty"`
+	YourCountry           string        `json:"your_country,omitempty"`
+	YourRegistrationNo    string        `json:"your_registration_no,omitempty"`
+	YourVATNo             string        `json:"your_vat_no,omitempty"`
+	PaymentMethod         string        `json:"payment_method,omitempty"`
+	BankAccount           string        `json:"bank_account,omitempty"`
+	IBAN                  string        `json:"iban,omitempty"`
+	SwiftBIC              string        `json:"swift_bic,omitempty"`
+	VariableSymbol        string        `json:"variable_symbol,omitempty"`
+	BankAccountID         int           `json:"bank_account_id,omitempty"`
+	NumberFormatID        int           `json:"number_format_id,omitempty"`
+	Language              string        `json:"language,omitempty"`
+	PublicHTMLURL         string        `json:"public_html_url,omitempty"`
+	Tags                  []string      `json:"tags,omitempty"`
+	Attachments           []Attachment  `json:"attachments,omitempty"`
 }
 
 type InvoiceLine struct {
-	Name      string      `json:"name"`
-	Quantity  json.Number `json:"quantity"`
-	UnitName  string      `json:"unit_name,omitempty"`
-	UnitPrice json.Number `json:"unit_price"`
-	VATRate   json.Number `json:"vat_rate"`
+	Name            string `json:"name"`
+	Quantity        Amount `json:"quantity"`
+	UnitName        string `json:"unit_name,omitempty"`
+	UnitPrice       Amount `json:"unit_price"`
+	VATRate         Amount `json:"vat_rate"`
+	InventoryItemID int    `json:"inventory_item_id,omitempty"`
+	// Inventory is returned by the API for lines linked to an inventory item.
+	Inventory *InvoiceLineInventory `json:"inventory,omitempty"`
+}
+
+type InvoiceLineInventory struct {
+	ItemID int    `json:"item_id"`
+	SKU    string `json:"sku,omitempty"`
+	MoveID int    `json:"move_id,omitempty"`
 }
 
 type CreateInvoiceRequest struct {
-	SubjectID int           `json:"subject_id"`
-	Lines     []InvoiceLine `json:"lines"`
-	Currency  string        `json:"currency,omitempty"`
-	Note      string        `json:"note,omitempty"`
-	DueOn     string        `json:"due_on,omitempty"`
-	IssuedOn  string        `json:"issued_on,omitempty"`
-	TaxableFulfillmentDue string `json:"taxable_fulfillment_due,omitempty"`
+	SubjectID             int           `json:"subject_id"`
+	Lines                 []InvoiceLine `json:"lines"`
+	Currency              string        `json:"currency,omitempty"`
+	Note                  string        `json:"note,omitempty"`
+	DueOn                 string        `json:"due_on,omitempty"`
+	IssuedOn              string        `json:"issued_on,omitempty"`
+	TaxableFulfillmentDue string        `json:"taxable_fulfillment_due,omitempty"`
+	ExchangeRate          *Amount       `json:"exchange_rate,omitempty"`
+	VATPriceMode          string        `json:"vat_price_mode,omitempty"`
+	BankAccountID         int           `json:"bank_account_id,omitempty"`
+	NumberFormatID        int           `json:"number_format_id,omitempty"`
+	DocumentType          string        `json:"document_type,omitempty"`
+	PaymentMethod         string        `json:"payment_method,omitempty"`
+	FooterNote            string        `json:"footer_note,omitempty"`
+	Language              string        `json:"language,omitempty"`
+	Tags                  []string      `json:"tags,omitempty"`
 }
 
 type UpdateInvoiceRequest struct {
-	SubjectID *int          `json:"subject_id,omitempty"`
-	Lines     []InvoiceLine `json:"lines,omitempty"`
-	Currency  string        `json:"currency,omitempty"`
-	Note      string        `json:"note,omitempty"`
-	DueOn     string        `json:"due_on,omitempty"`
+	SubjectID   *int               `json:"subject_id,omitempty"`
+	Lines       []InvoiceLine      `json:"lines,omitempty"`
+	Currency    string             `json:"currency,omitempty"`
+	Note        string             `json:"note,omitempty"`
+	DueOn       string             `json:"due_on,omitempty"`
+	Attachments []AttachmentUpload `json:"attachments,omitempty"`
 }
 
 type SendInvoiceRequest struct {
@@ -60,18 +110,18 @@ type SendInvoiceRequest struct {
 // --- Subject (Contact) ---
 
 type Subject struct {
-	ID               int    `json:"id"`
-	Name             string `json:"name"`
-	Street           string `json:"street,omitempty"`
-	City             string `json:"city,omitempty"`
-	Zip              string `json:"zip,omitempty"`
-	Country          string `json:"country,omitempty"`
-	RegistrationNo   string `json:"registration_no,omitempty"`
-	VATNo            string `json:"vat_no,omitempty"`
-	Email            string `json:"email,omitempty"`
-	Phone            string `json:"phone,omitempty"`
-	FullName         string `json:"full_name,omitempty"`
-	Type             string `json:"type,omitempty"`
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	Street         string `json:"street,omitempty"`
+	City           string `json:"city,omitempty"`
+	Zip            string `json:"zip,omitempty"`
+	Country        string `json:"country,omitempty"`
+	RegistrationNo string `json:"registration_no,omitempty"`
+	VATNo          string `json:"vat_no,omitempty"`
+	Email          string `json:"email,omitempty"`
+	Phone          string `json:"phone,omitempty"`
+	FullName       string `json:"full_name,omitempty"`
+	Type           string `json:"type,omitempty"`
 }
 
 type CreateSubjectRequest struct {
@@ -101,46 +151,114 @@ type UpdateSubjectRequest struct {
 // --- Expense ---
 
 type Expense struct {
-	ID              int          `json:"id"`
-	Number          string       `json:"number"`
-	OriginalNumber  string       `json:"original_number,omitempty"`
-	SubjectID       int          `json:"subject_id"`
-	Status          string       `json:"status"`
-	IssuedOn        string       `json:"issued_on"`
-	DueOn           string       `json:"due_on"`
-	PaidOn          string       `json:"paid_on,omitempty"`
-	Currency        string       `json:"currency"`
-	NativeTotal     string       `json:"native_total"`
-	Total           string       `json:"total"`
-	Lines           []ExpenseLine `json:"lines,omitempty"`
-	SubjectName     string       `json:"subject_name,omitempty"`
+	ID                    int           `json:"id"`
+	Number                string        `json:"number"`
+	OriginalNumber        string        `json:"original_number,omitempty"`
+	SubjectID             int           `json:"subject_id"`
+	Status                string        `json:"status"`
+	IssuedOn              string        `json:"issued_on"`
+	TaxableFulfillmentDue string        `json:"taxable_fulfillment_due,omitempty"`
+	DueOn                 string        `json:"due_on"`
+	PaidOn                string        `json:"paid_on,omitempty"`
+	Currency              string        `json:"currency"`
+	ExchangeRate          Amount        `json:"exchange_rate"`
+	VATPriceMode          string        `json:"vat_price_mode,omitempty"`
+	Subtotal              Amount        `json:"subtotal"`
+	NativeSubtotal        Amount        `json:"native_subtotal"`
+	NativeTotal           Amount        `json:"native_total"`
+	Total                 Amount        `json:"total"`
+	Lines                 []ExpenseLine `json:"lines,omitempty"`
+	SubjectName           string        `json:"subject_name,omitempty"`
+	SupplierName          string        `json:"supplier_name,omitempty"`
+	SupplierVATNo         string        `json:"supplier_vat_no,omitempty"`
+	VariableSymbol        string        `json:"variable_symbol,omitempty"`
+	BankAccount           string        `json:"bank_account,omitempty"`
+	IBAN                  string        `json:"iban,omitempty"`
+	PaymentMethod         string        `json:"payment_method,omitempty"`
+	Tags                  []string      `json:"tags,omitempty"`
+	Attachments           []Attachment  `json:"attachments,omitempty"`
+}
+
+type CreateExpenseRequest struct {
+	SubjectID             int                `json:"subject_id"`
+	OriginalNumber        string             `json:"original_number,omitempty"`
+	DocumentType          string             `json:"document_type,omitempty"`
+	IssuedOn              string             `json:"issued_on,omitempty"`
+	TaxableFulfillmentDue string             `json:"taxable_fulfillment_due,omitempty"`
+	DueOn                 string             `json:"due_on,omitempty"`
+	VariableSymbol        string             `json:"variable_symbol,omitempty"`
+	BankAccount           string             `json:"bank_account,omitempty"`
+	IBAN                  string             `json:"iban,omitempty"`
+	SwiftBIC              string             `json:"swift_bic,omitempty"`
+	PaymentMethod         string             `json:"payment_method,omitempty"`
+	Currency              string             `json:"currency,omitempty"`
+	ExchangeRate          *Amount            `json:"exchange_rate,omitempty"`
+	VATPriceMode          string             `json:"vat_price_mode,omitempty"`
+	Description           string             `json:"description,omitempty"`
+	Lines                 []ExpenseLine      `json:"lines"`
+	Attachments           []AttachmentUpload `json:"attachments,omitempty"`
+}
+
+type UpdateExpenseRequest struct {
+	Attachments []AttachmentUpload `json:"attachments,omitempty"`
 }
 
 type ExpenseLine struct {
-	Name      string      `json:"name"`
-	Quantity  json.Number `json:"quantity"`
-	UnitName  string      `json:"unit_name,omitempty"`
-	UnitPrice json.Number `json:"unit_price"`
-	VATRate   json.Number `json:"vat_rate"`
+	Name      string `json:"name"`
+	Quantity  Amount `json:"quantity"`
+	UnitName  string `json:"unit_name,omitempty"`
+	UnitPrice Amount `json:"unit_price"`
+	VATRate   Amount `json:"vat_rate"`
 }
 
 // --- Account ---
 
 type Account struct {
-	Subdomain        string `json:"subdomain"`
-	Plan             string `json:"plan"`
-	PlanPrice        int    `json:"plan_price"`
-	Email            string `json:"email"`
-	InvoiceEmail     string `json:"invoice_email,omitempty"`
-	Phone            string `json:"phone,omitempty"`
-	Name             string `json:"name"`
-	RegistrationNo   string `json:"registration_no,omitempty"`
-	VATNo            string `json:"vat_no,omitempty"`
-	Street           string `json:"street,omitempty"`
-	City             string `json:"city,omitempty"`
-	Zip              string `json:"zip,omitempty"`
-	Country          string `json:"country,omitempty"`
-	Currency         string `json:"currency"`
+	Subdomain            string `json:"subdomain"`
+	Plan                 string `json:"plan"`
+	PlanPrice            int    `json:"plan_price"`
+	Email                string `json:"email"`
+	InvoiceEmail         string `json:"invoice_email,omitempty"`
+	Phone                string `json:"phone,omitempty"`
+	Name                 string `json:"name"`
+	RegistrationNo       string `json:"registration_no,omitempty"`
+	VATNo                string `json:"vat_no,omitempty"`
+	Street               string `json:"street,omitempty"`
+	City                 string `json:"city,omitempty"`
+	Zip                  string `json:"zip,omitempty"`
+	Country              string `json:"country,omitempty"`
+	Currency             string `json:"currency"`
+	VATMode              string `json:"vat_mode,omitempty"`
+	VATPriceMode         string `json:"vat_price_mode,omitempty"`
+	Due                  int    `json:"due"`
+	InvoiceLanguage      string `json:"invoice_language,omitempty"`
+	InvoicePaymentMethod string `json:"invoice_payment_method,omitempty"`
+	OverdueEmailDays     int    `json:"overdue_email_days,omitempty"`
+}
+
+type BankAccount struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Currency string `json:"currency"`
+	Number   string `json:"number,omitempty"`
+	IBAN     string `json:"iban,omitempty"`
+	SwiftBIC string `json:"swift_bic,omitempty"`
+	Pairing  bool   `json:"pairing"`
+	Default  bool   `json:"default"`
+}
+
+type NumberFormat struct {
+	ID      int    `json:"id"`
+	Format  string `json:"format"`
+	Preview string `json:"preview"`
+	Default bool   `json:"default"`
+}
+
+type User struct {
+	ID         int    `json:"id"`
+	FullName   string `json:"full_name"`
+	Email      string `json:"email"`
+	Permission string `json:"permission,omitempty"`
 }
 
 // --- Event ---
@@ -154,8 +272,185 @@ type Event struct {
 // --- InvoicePayment ---
 
 type InvoicePayment struct {
+	ID             int    `json:"id"`
+	PaidOn         string `json:"paid_on"`
+	Amount         Amount `json:"amount"`
+	Currency       string `json:"currency"`
+	VariableSymbol string `json:"variable_symbol,omitempty"`
+}
+
+type CreateInvoicePaymentRequest struct {
+	PaidOn             string  `json:"paid_on,omitempty"`
+	Currency           string  `json:"currency,omitempty"`
+	Amount             *Amount `json:"amount,omitempty"`
+	VariableSymbol     string  `json:"variable_symbol,omitempty"`
+	BankAccountID      int     `json:"bank_account_id,omitempty"`
+	MarkDocumentAsPaid *bool   `json:"mark_document_as_paid,omitempty"`
+}
+
+// --- ExpensePayment ---
+
+type ExpensePayment struct {
 	ID       int    `json:"id"`
 	PaidOn   string `json:"paid_on"`
-	Amount   string `json:"amount"`
+	Amount   Amount `json:"amount"`
 	Currency string `json:"currency"`
 }
+
+type CreateExpensePaymentRequest struct {
+	PaidOn             string  `json:"paid_on,omitempty"`
+	Currency           string  `json:"currency,omitempty"`
+	Amount             *Amount `json:"amount,omitempty"`
+	VariableSymbol     string  `json:"variable_symbol,omitempty"`
+	MarkDocumentAsPaid *bool   `json:"mark_document_as_paid,omitempty"`
+}
+
+// --- Inventory ---
+
+type InventoryItem struct {
+	ID                  int    `json:"id"`
+	Name                string `json:"name"`
+	SKU                 string `json:"sku,omitempty"`
+	ArticleNumberType   string `json:"article_number_type,omitempty"`
+	ArticleNumber       string `json:"article_number,omitempty"`
+	UnitName            string `json:"unit_name,omitempty"`
+	TrackQuantity       bool   `json:"track_quantity"`
+	Quantity            Amount `json:"quantity"`
+	MinQuantity         Amount `json:"min_quantity"`
+	MaxQuantity         Amount `json:"max_quantity"`
+	AllowBelowZero      bool   `json:"allow_below_zero"`
+	LowQuantityDate     string `json:"low_quantity_date,omitempty"`
+	NativePurchasePrice Amount `json:"native_purchase_price"`
+	NativeRetailPrice   Amount `json:"native_retail_price"`
+	VATRate             string `json:"vat_rate,omitempty"`
+	Archived            bool   `json:"archived"`
+	PrivateNote         string `json:"private_note,omitempty"`
+	SupplyType          string `json:"supply_type,omitempty"`
+	UpdatedAt           string `json:"updated_at,omitempty"`
+}
+
+type CreateInventoryItemRequest struct {
+	Name                string  `json:"name"`
+	SKU                 string  `json:"sku,omitempty"`
+	ArticleNumberType   string  `json:"article_number_type,omitempty"`
+	ArticleNumber       string  `json:"article_number,omitempty"`
+	UnitName            string  `json:"unit_name,omitempty"`
+	TrackQuantity       bool    `json:"track_quantity,omitempty"`
+	Quantity            *Amount `json:"quantity,omitempty"`
+	MinQuantity         *Amount `json:"min_quantity,omitempty"`
+	MaxQuantity         *Amount `json:"max_quantity,omitempty"`
+	AllowBelowZero      bool    `json:"allow_below_zero,omitempty"`
+	NativePurchasePrice *Amount `json:"native_purchase_price,omitempty"`
+	NativeRetailPrice   *Amount `json:"native_retail_price,omitempty"`
+	VATRate             string  `json:"vat_rate,omitempty"`
+	PrivateNote         string  `json:"private_note,omitempty"`
+	SupplyType          string  `json:"supply_type,omitempty"`
+}
+
+type UpdateInventoryItemRequest struct {
+	Name                string  `json:"name,omitempty"`
+	SKU                 string  `json:"sku,omitempty"`
+	ArticleNumberType   string  `json:"article_number_type,omitempty"`
+	ArticleNumber       string  `json:"article_number,omitempty"`
+	UnitName            string  `json:"unit_name,omitempty"`
+	TrackQuantity       *bool   `json:"track_quantity,omitempty"`
+	MinQuantity         *Amount `json:"min_quantity,omitempty"`
+	MaxQuantity         *Amount `json:"max_quantity,omitempty"`
+	AllowBelowZero      *bool   `json:"allow_below_zero,omitempty"`
+	NativePurchasePrice *Amount `json:"native_purchase_price,omitempty"`
+	NativeRetailPrice   *Amount `json:"native_retail_price,omitempty"`
+	VATRate             string  `json:"vat_rate,omitempty"`
+	PrivateNote         string  `json:"private_note,omitempty"`
+	SupplyType          string  `json:"supply_type,omitempty"`
+}
+
+type InventoryMove struct {
+	ID                  int                    `json:"id"`
+	InventoryItemID     int                    `json:"inventory_item_id"`
+	Direction           string                 `json:"direction"`
+	MovedOn             string                 `json:"moved_on"`
+	QuantityChange      Amount                 `json:"quantity_change"`
+	PurchasePrice       Amount                 `json:"purchase_price"`
+	PurchaseCurrency    string                 `json:"purchase_currency,omitempty"`
+	NativePurchasePrice Amount                 `json:"native_purchase_price"`
+	RetailPrice         Amount                 `json:"retail_price"`
+	RetailCurrency      string                 `json:"retail_currency,omitempty"`
+	NativeRetailPrice   Amount                 `json:"native_retail_price"`
+	PrivateNote         string                 `json:"private_note,omitempty"`
+	Document            *InventoryMoveDocument `json:"document,omitempty"`
+	CreatedAt           string                 `json:"created_at,omitempty"`
+}
+
+type InventoryMoveDocument struct {
+	ID     int    `json:"id"`
+	Type   string `json:"type"`
+	LineID int    `json:"line_id,omitempty"`
+}
+
+type CreateInventoryMoveRequest struct {
+	Direction        string  `json:"direction"`
+	MovedOn          string  `json:"moved_on,omitempty"`
+	QuantityChange   Amount  `json:"quantity_change"`
+	PurchasePrice    *Amount `json:"purchase_price,omitempty"`
+	PurchaseCurrency string  `json:"purchase_currency,omitempty"`
+	RetailPrice      *Amount `json:"retail_price,omitempty"`
+	RetailCurrency   string  `json:"retail_currency,omitempty"`
+	PrivateNote      string  `json:"private_note,omitempty"`
+}
+
+// --- Webhook ---
+
+type Webhook struct {
+	ID         int      `json:"id"`
+	WebhookURL string   `json:"webhook_url"`
+	AuthHeader string   `json:"auth_header,omitempty"`
+	Active     bool     `json:"active"`
+	Events     []string `json:"events"`
+	CreatedAt  string   `json:"created_at,omitempty"`
+	UpdatedAt  string   `json:"updated_at,omitempty"`
+}
+
+type CreateWebhookRequest struct {
+	WebhookURL string   `json:"webhook_url"`
+	AuthHeader string   `json:"auth_header,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+	Events     []string `json:"events"`
+}
+
+type UpdateWebhookRequest struct {
+	WebhookURL string   `json:"webhook_url,omitempty"`
+	AuthHeader string   `json:"auth_header,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+	Events     []string `json:"events,omitempty"`
+}
+
+// --- Attachments and inbox ---
+
+type Attachment struct {
+	ID          int    `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// AttachmentUpload attaches a file to a document. DataURL is a base64 data URI (see DataURL).
+type AttachmentUpload struct {
+	Filename string `json:"filename,omitempty"`
+	DataURL  string `json:"data_url"`
+}
+
+type InboxFile struct {
+	ID               int    `json:"id"`
+	Filename         string `json:"filename"`
+	Bytesize         int    `json:"bytesize"`
+	SendToOCR        bool   `json:"send_to_ocr"`
+	OCRStatus        string `json:"ocr_status,omitempty"`
+	OCRStatusMessage string `json:"ocr_status_message,omitempty"`
+	CreatedAt        string `json:"created_at,omitempty"`
+}
+
+type CreateInboxFileRequest struct {
+	Attachment string `json:"attachment"`
+	Filename   string `json:"filename,omitempty"`
+	SendToOCR  bool   `json:"send_to_ocr,omitempty"`
+}
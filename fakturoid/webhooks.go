@@ -0,0 +1,31 @@
+package fakturoid
+
+import "fmt"
+
+func (c *Client) GetWebhooks() ([]Webhook, error) {
+	var result []Webhook
+	err := c.do("GET", "/webhooks.json", nil, &result)
+	return result, err
+}
+
+func (c *Client) GetWebhook(id int) (*Webhook, error) {
+	var result Webhook
+	err := c.do("GET", fmt.Sprintf("/webhooks/%d.json", id), nil, &result)
+	return &result, err
+}
+
+func (c *Client) CreateWebhook(req CreateWebhookRequest) (*Webhook, error) {
+	var result Webhook
+	err := c.do("POST", "/webhooks.json", req, &result)
+	return &result, err
+}
+
+func (c *Client) UpdateWebhook(id int, req UpdateWebhookRequest) (*Webhook, error) {
+	var result Webhook
+	err := c.do("PATCH", fmt.Sprintf("/webhooks/%d.json", id), req, &result)
+	return &result, err
+}
+
+func (c *Client) DeleteWebhook(id int) error {
+	return c.do("DELETE", fmt.Sprintf("/webhooks/%d.json", id), nil, nil)
+}
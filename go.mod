@@ -2,7 +2,10 @@ module github.com/tedyno/fakturoid-mcp
 
 go 1.25.5
 
-require github.com/mark3labs/mcp-go v0.43.2
+require (
+	github.com/mark3labs/mcp-go v0.43.2
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
+)
 
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
@@ -0,0 +1,339 @@
+package isdoc
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+const issuingSystem = "fakturoid-mcp"
+
+// countryNames are the Czech names of common countries; other codes are used as the name.
+var countryNames = map[string]string{
+	"CZ": "Česká republika",
+	"SK": "Slovensko",
+	"DE": "Německo",
+	"AT": "Rakousko",
+	"PL": "Polsko",
+	"HU": "Maďarsko",
+	"GB": "Spojené království",
+	"US": "Spojené státy americké",
+}
+
+// bankNames are the names of Czech banks by bank code, for the payment details.
+var bankNames = map[string]string{
+	"0100": "Komerční banka, a.s.",
+	"0300": "Československá obchodní banka, a. s.",
+	"0600": "MONETA Money Bank, a.s.",
+	"0710": "Česká národní banka",
+	"0800": "Česká spořitelna, a.s.",
+	"2010": "Fio banka, a.s.",
+	"2250": "Banka CREDITAS a.s.",
+	"2700": "UniCredit Bank Czech Republic and Slovakia, a.s.",
+	"3030": "Air Bank a.s.",
+	"5500": "Raiffeisenbank a.s.",
+	"6210": "mBank S.A., organizační složka",
+}
+
+// FromInvoice converts a Fakturoid invoice into an ISDOC document. Seller and buyer details
+// are taken from the invoice and completed from the account and subject when missing.
+// Line and VAT amounts are recalculated from the lines the same way Fakturoid does; an
+// invoice whose recalculated totals differ from Fakturoid's is refused.
+func FromInvoice(inv fakturoid.Invoice, account fakturoid.Account, subject *fakturoid.Subject) (*Invoice, error) {
+	if len(inv.Lines) == 0 {
+		return nil, fmt.Errorf("invoice %s has no lines", inv.Number)
+	}
+	local := strings.ToUpper(account.Currency)
+	currency := strings.ToUpper(inv.Currency)
+	if currency == "" {
+		currency = local
+	}
+	foreign := currency != local
+	rate := fakturoid.NewAmount(1)
+	if foreign {
+		if inv.ExchangeRate.Sign() <= 0 {
+			return nil, fmt.Errorf("invoice %s in %s has no exchange rate to %s", inv.Number, currency, local)
+		}
+		rate = inv.ExchangeRate
+	}
+
+	preview := fakturoid.PreviewInvoice(inv.Lines, fakturoid.PreviewOptions{
+		Currency:     currency,
+		VATPriceMode: inv.VATPriceMode,
+		VATPayer:     account.VATPayer(),
+	})
+	if err := checkTotals(inv, preview); err != nil {
+		return nil, err
+	}
+	vatApplicable := account.VATPayer() && !isProforma(inv)
+
+	doc := &Invoice{
+		Xmlns:             Namespace,
+		Version:           Version,
+		DocumentType:      documentType(inv),
+		ID:                inv.Number,
+		UUID:              documentUUID(account.Subdomain, inv.ID),
+		IssuingSystem:     issuingSystem,
+		IssueDate:         inv.IssuedOn,
+		VATApplicable:     vatApplicable,
+		Note:              inv.Note,
+		LocalCurrencyCode: local,
+		CurrRate:          "1",
+		RefCurrRate:       "1",
+		Supplier:          PartyWrapper{Party: sellerParty(inv, account)},
+		Customer:          PartyWrapper{Party: buyerParty(inv, subject)},
+	}
+	if vatApplicable {
+		doc.TaxPointDate = firstNonEmpty(inv.TaxableFulfillmentDue, inv.IssuedOn)
+	}
+	if foreign {
+		doc.ForeignCurrencyCode = currency
+		doc.CurrRate = rate.String()
+	}
+
+	// amount returns the local currency value and, for foreign invoices, the original one.
+	amount := func(a fakturoid.Amount) (string, string) {
+		if !foreign {
+			return a.StringFixed(2), ""
+		}
+		return a.Mul(rate).Round(2, fakturoid.RoundHalfUp).StringFixed(2), a.StringFixed(2)
+	}
+
+	decimals := fakturoid.CurrencyDecimals(currency)
+	method := 0
+	if preview.VATPriceMode == fakturoid.VATPriceModeWithVAT {
+		method = 1
+	}
+	for i, l := range preview.Lines {
+		net, vat, gross := lineAmounts(l, preview.VATPriceMode, decimals)
+		line := Line{
+			ID:               fmt.Sprintf("%d", i+1),
+			InvoicedQuantity: &Quantity{UnitCode: inv.Lines[i].UnitName, Value: l.Quantity.String()},
+			ClassifiedTaxCategory: TaxCategory{
+				Percent:              l.VATRate.String(),
+				VATCalculationMethod: &method,
+			},
+			Item: &Item{Description: l.Name},
+		}
+		line.LineExtensionAmount, line.LineExtensionAmountCurr = amount(net)
+		line.LineExtensionAmountTaxInclusive, line.LineExtensionAmountTaxInclusiveCurr = amount(gross)
+		line.LineExtensionTaxAmount, _ = amount(vat)
+		line.UnitPrice, line.UnitPriceTaxInclusive = unitPrices(l, net, gross, rate)
+		doc.Lines = append(doc.Lines, line)
+	}
+
+	for _, s := range preview.VATRates {
+		sub := TaxSubTotal{
+			AlreadyClaimedTaxableAmount:      "0",
+			AlreadyClaimedTaxAmount:          "0",
+			AlreadyClaimedTaxInclusiveAmount: "0",
+			TaxCategory:                      TaxCategory{Percent: s.Rate.String()},
+		}
+		sub.TaxableAmount, sub.TaxableAmountCurr = amount(s.Base)
+		sub.TaxAmount, sub.TaxAmountCurr = amount(s.VAT)
+		sub.TaxInclusiveAmount, sub.TaxInclusiveAmountCurr = amount(s.Total)
+		sub.DifferenceTaxableAmount = sub.TaxableAmount
+		sub.DifferenceTaxAmount = sub.TaxAmount
+		sub.DifferenceTaxInclusiveAmount = sub.TaxInclusiveAmount
+		doc.TaxTotal.SubTotals = append(doc.TaxTotal.SubTotals, sub)
+	}
+	doc.TaxTotal.TaxAmount, doc.TaxTotal.TaxAmountCurr = amount(preview.VAT)
+
+	// total returns Fakturoid's amount, using its native (local) value when there is one.
+	total := func(a, native fakturoid.Amount) (string, string) {
+		local, curr := amount(a)
+		if foreign && !native.IsZero() {
+			local = native.StringFixed(2)
+		}
+		return local, curr
+	}
+
+	t := &doc.LegalMonetaryTotal
+	t.TaxExclusiveAmount, t.TaxExclusiveAmountCurr = total(inv.Subtotal, inv.NativeSubtotal)
+	t.TaxInclusiveAmount, t.TaxInclusiveAmountCurr = amount(preview.TotalBefore)
+	t.AlreadyClaimedTaxExclusiveAmount = "0"
+	t.AlreadyClaimedTaxInclusiveAmount = "0"
+	t.DifferenceTaxExclusiveAmount, t.DifferenceTaxExclusiveAmountCurr = t.TaxExclusiveAmount, t.TaxExclusiveAmountCurr
+	t.DifferenceTaxInclusiveAmount, t.DifferenceTaxInclusiveAmountCurr = t.TaxInclusiveAmount, t.TaxInclusiveAmountCurr
+	if !preview.Rounding.IsZero() {
+		t.PayableRoundingAmount, t.PayableRoundingAmountCurr = amount(preview.Rounding)
+	}
+	t.PaidDepositsAmount = "0"
+	t.PayableAmount, t.PayableAmountCurr = total(inv.Total, inv.NativeTotal)
+
+	doc.PaymentMeans = paymentMeans(inv, t.PayableAmount)
+	return doc, nil
+}
+
+// checkTotals compares the totals recalculated from the lines with those Fakturoid reports.
+func checkTotals(inv fakturoid.Invoice, preview fakturoid.InvoicePreview) error {
+	if preview.Total.Cmp(inv.Total) != 0 || preview.Subtotal.Cmp(inv.Subtotal) != 0 {
+		return fmt.Errorf("invoice %s: totals recalculated from the lines (%s, %s without VAT) differ from Fakturoid's (%s, %s without VAT)",
+			inv.Number, preview.Total.StringFixed(2), preview.Subtotal.StringFixed(2), inv.Total.StringFixed(2), inv.Subtotal.StringFixed(2))
+	}
+	return nil
+}
+
+// lineAmounts splits a line total into base, VAT and total with VAT.
+func lineAmounts(l fakturoid.LinePreview, mode string, decimals int) (net, vat, gross fakturoid.Amount) {
+	if mode == fakturoid.VATPriceModeWithVAT {
+		v, err := l.Total.Mul(l.VATRate).Div(fakturoid.NewAmount(100).Add(l.VATRate))
+		if err != nil {
+			v = fakturoid.Amount{}
+		}
+		vat = v.Round(decimals, fakturoid.RoundHalfUp)
+		return l.Total.Sub(vat), vat, l.Total
+	}
+	vat = l.Total.Percent(l.VATRate).Round(decimals, fakturoid.RoundHalfUp)
+	return l.Total, vat, l.Total.Add(vat)
+}
+
+// unitPrices returns the unit price without and with VAT in local currency.
+func unitPrices(l fakturoid.LinePreview, net, gross, rate fakturoid.Amount) (string, string) {
+	if l.Quantity.IsZero() {
+		return "0", "0"
+	}
+	unit := func(total fakturoid.Amount) string {
+		u, err := total.Mul(rate).Div(l.Quantity)
+		if err != nil {
+			return "0"
+		}
+		return u.Round(4, fakturoid.RoundHalfUp).String()
+	}
+	return unit(net), unit(gross)
+}
+
+func documentType(inv fakturoid.Invoice) int {
+	switch inv.DocumentType {
+	case "proforma", "partial_proforma":
+		return TypeProforma
+	case "tax_document":
+		return TypeAdvanceTaxDoc
+	case "correction":
+		if inv.Total.Sign() < 0 {
+			return TypeCreditNote
+		}
+		return TypeDebitNote
+	}
+	return TypeInvoice
+}
+
+func isProforma(inv fakturoid.Invoice) bool {
+	return documentType(inv) == TypeProforma
+}
+
+// documentUUID derives a stable name-based UUID (version 5 layout) so repeated exports
+// of the same invoice carry the same identifier.
+func documentUUID(account string, id int) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("fakturoid:%s:invoice:%d", account, id)))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return strings.ToUpper(fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16]))
+}
+
+func sellerParty(inv fakturoid.Invoice, account fakturoid.Account) Party {
+	return party(
+		firstNonEmpty(inv.YourName, account.Name),
+		firstNonEmpty(inv.YourStreet, account.Street),
+		firstNonEmpty(inv.YourCity, account.City),
+		firstNonEmpty(inv.YourZip, account.Zip),
+		firstNonEmpty(inv.YourCountry, account.Country),
+		firstNonEmpty(inv.YourRegistrationNo, account.RegistrationNo),
+		firstNonEmpty(inv.YourVATNo, account.VATNo),
+		&Contact{Telephone: account.Phone, Email: firstNonEmpty(account.InvoiceEmail, account.Email)},
+	)
+}
+
+func buyerParty(inv fakturoid.Invoice, subject *fakturoid.Subject) Party {
+	if subject == nil {
+		subject = &fakturoid.Subject{}
+	}
+	var contact *Contact
+	if subject.Email != "" || subject.Phone != "" {
+		contact = &Contact{Telephone: subject.Phone, Email: subject.Email}
+	}
+	return party(
+		firstNonEmpty(inv.ClientName, subject.Name),
+		firstNonEmpty(inv.ClientStreet, subject.Street),
+		firstNonEmpty(inv.ClientCity, subject.City),
+		firstNonEmpty(inv.ClientZip, subject.Zip),
+		firstNonEmpty(inv.ClientCountry, subject.Country),
+		firstNonEmpty(inv.ClientRegistrationNo, subject.RegistrationNo),
+		firstNonEmpty(inv.ClientVATNo, subject.VATNo),
+		contact,
+	)
+}
+
+func party(name, street, city, zip, country, registrationNo, vatNo string, contact *Contact) Party {
+	country = strings.ToUpper(firstNonEmpty(country, "CZ"))
+	streetName, number := splitStreet(street)
+	p := Party{
+		ID:   registrationNo,
+		Name: name,
+		Address: Address{
+			StreetName:     streetName,
+			BuildingNumber: number,
+			CityName:       city,
+			PostalZone:     zip,
+			Country:        Country{IdentificationCode: country, Name: firstNonEmpty(countryNames[country], country)},
+		},
+	}
+	if vatNo != "" {
+		p.TaxSchemes = []PartyTaxScheme{{CompanyID: vatNo, TaxScheme: "VAT"}}
+	}
+	if contact != nil && (contact.Telephone != "" || contact.Email != "") {
+		p.Contact = contact
+	}
+	return p
+}
+
+// splitStreet separates a trailing building number ("Dlouhá 12/3a") from the street name.
+func splitStreet(street string) (string, string) {
+	street = strings.TrimSpace(street)
+	i := strings.LastIndex(street, " ")
+	if i < 0 {
+		return street, ""
+	}
+	number := street[i+1:]
+	if number == "" || !unicode.IsDigit(rune(number[0])) {
+		return street, ""
+	}
+	return street[:i], number
+}
+
+func paymentMeans(inv fakturoid.Invoice, payable string) *PaymentMeans {
+	payment := Payment{PaidAmount: payable}
+	switch inv.PaymentMethod {
+	case "cash":
+		payment.PaymentMeansCode = PaymentCash
+	case "card":
+		payment.PaymentMeansCode = PaymentCard
+	case "cod":
+		payment.PaymentMeansCode = PaymentCOD
+	default:
+		payment.PaymentMeansCode = PaymentTransfer
+		number, bankCode, _ := strings.Cut(inv.BankAccount, "/")
+		payment.Details = &PaymentDetails{
+			PaymentDueDate: inv.DueOn,
+			ID:             number,
+			BankCode:       bankCode,
+			Name:           bankNames[bankCode],
+			IBAN:           strings.ReplaceAll(inv.IBAN, " ", ""),
+			BIC:            inv.SwiftBIC,
+			VariableSymbol: inv.VariableSymbol,
+		}
+	}
+	return &PaymentMeans{Payments: []Payment{payment}}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package isdoc
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// golden compares got with testdata/name, or rewrites the file with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n%s", name, got)
+	}
+}
+
+var testAccount = fakturoid.Account{
+	Subdomain:      "example",
+	Name:           "Example s.r.o.",
+	Email:          "fakturace@example.com",
+	RegistrationNo: "01234567",
+	VATNo:          "CZ01234567",
+	Street:         "Dlouhá 12/3a",
+	City:           "Praha",
+	Zip:            "110 00",
+	Country:        "CZ",
+	Currency:       "CZK",
+	VATMode:        "vat_payer",
+}
+
+func testInvoice() fakturoid.Invoice {
+	return fakturoid.Invoice{
+		ID:                    42,
+		Number:                "2026-0042",
+		SubjectID:             7,
+		DocumentType:          "invoice",
+		IssuedOn:              "2026-03-02",
+		TaxableFulfillmentDue: "2026-02-28",
+		DueOn:                 "2026-03-16",
+		Currency:              "CZK",
+		VATPriceMode:          fakturoid.VATPriceModeWithoutVAT,
+		Subtotal:              fakturoid.MustParseAmount("3803.4"),
+		Total:                 fakturoid.NewAmount(4593),
+		Lines: []fakturoid.InvoiceLine{
+			{Name: "Konzultace", Quantity: fakturoid.NewAmount(3), UnitName: "h", UnitPrice: fakturoid.MustParseAmount("1234.5"), VATRate: fakturoid.NewAmount(21)},
+			{Name: "Kniha", Quantity: fakturoid.NewAmount(1), UnitName: "ks", UnitPrice: fakturoid.MustParseAmount("99.9"), VATRate: fakturoid.NewAmount(12)},
+		},
+		ClientName:           "Odběratel a.s.",
+		ClientStreet:         "Krátká 5",
+		ClientCity:           "Brno",
+		ClientZip:            "602 00",
+		ClientCountry:        "CZ",
+		ClientRegistrationNo: "87654321",
+		ClientVATNo:          "CZ87654321",
+		PaymentMethod:        "bank",
+		BankAccount:          "1234567890/2010",
+		IBAN:                 "CZ65 2010 0000 0012 3456 7890",
+		SwiftBIC:             "FIOBCZPPXXX",
+		VariableSymbol:       "20260042",
+	}
+}
+
+func TestFromInvoiceGolden(t *testing.T) {
+	subject := &fakturoid.Subject{Email: "ucetni@odberatel.cz"}
+	doc, err := FromInvoice(testInvoice(), testAccount, subject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "invoice.isdoc", data)
+}
+
+func TestFromInvoiceForeignGolden(t *testing.T) {
+	inv := testInvoice()
+	inv.Currency = "EUR"
+	inv.ExchangeRate = fakturoid.MustParseAmount("25.125")
+	inv.Lines = inv.Lines[:1]
+	inv.Subtotal = fakturoid.MustParseAmount("3703.5")
+	inv.Total = fakturoid.MustParseAmount("4481.24")
+	// The local totals are Fakturoid's native ones.
+	inv.NativeSubtotal = fakturoid.MustParseAmount("93050.44")
+	inv.NativeTotal = fakturoid.MustParseAmount("112591.16")
+	inv.BankAccount = ""
+	doc, err := FromInvoice(inv, testAccount, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "invoice_eur.isdoc", data)
+}
+
+func TestFromInvoiceTotalsMismatch(t *testing.T) {
+	inv := testInvoice()
+	inv.Total = fakturoid.NewAmount(4594)
+	_, err := FromInvoice(inv, testAccount, nil)
+	if err == nil || !strings.Contains(err.Error(), "differ from Fakturoid's") {
+		t.Errorf("FromInvoice with a different total: error = %v", err)
+	}
+}
+
+func TestFromInvoiceNonVATPayer(t *testing.T) {
+	account := testAccount
+	account.VATMode = "non_vat_payer"
+	inv := testInvoice()
+	inv.Lines = []fakturoid.InvoiceLine{{Name: "Konzultace", Quantity: fakturoid.NewAmount(2), UnitPrice: fakturoid.NewAmount(500)}}
+	inv.Subtotal = fakturoid.NewAmount(1000)
+	inv.Total = fakturoid.NewAmount(1000)
+	doc, err := FromInvoice(inv, account, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.VATApplicable || doc.LegalMonetaryTotal.PayableAmount != "1000.00" {
+		t.Errorf("VATApplicable = %v, payable %s; want false, 1000.00", doc.VATApplicable, doc.LegalMonetaryTotal.PayableAmount)
+	}
+}
+
+func TestSplitStreet(t *testing.T) {
+	tests := []struct{ in, street, number string }{
+		{"Dlouhá 12/3a", "Dlouhá", "12/3a"},
+		{"Na Příkopě 1", "Na Příkopě", "1"},
+		{"Náměstí Míru", "Náměstí Míru", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		street, number := splitStreet(tt.in)
+		if street != tt.street || number != tt.number {
+			t.Errorf("splitStreet(%q) = %q, %q; want %q, %q", tt.in, street, number, tt.street, tt.number)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package isdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// maxExtractSize limits decompressed PDF streams.
+const maxExtractSize = 10 << 20
+
+// Extract returns the ISDOC XML contained in data, which may be a plain .isdoc file,
+// an .isdocx package (ZIP) or a PDF with the ISDOC embedded as an attachment.
+func Extract(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF")):
+		return extractFromPDF(data)
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return extractFromZip(data)
+	case isISDOC(data):
+		return data, nil
+	}
+	return nil, fmt.Errorf("not an ISDOC, ISDOCX or PDF file")
+}
+
+func isISDOC(data []byte) bool {
+	head := data
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	return bytes.Contains(head, []byte("<Invoice")) && bytes.Contains(head, []byte("isdoc.cz"))
+}
+
+func extractFromZip(data []byte) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("read isdocx: %w", err)
+	}
+	for _, f := range zr.File {
+		if !strings.EqualFold(path.Ext(f.Name), ".isdoc") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxExtractSize))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", f.Name, err)
+		}
+		return content, nil
+	}
+	return nil, fmt.Errorf("isdocx package contains no .isdoc file")
+}
+
+// extractFromPDF looks through the PDF's streams for the embedded ISDOC file. Embedded
+// files are usually Flate-compressed; other filters are not supported.
+func extractFromPDF(data []byte) ([]byte, error) {
+	rest := data
+	for {
+		i := bytes.Index(rest, []byte("stream"))
+		if i < 0 {
+			break
+		}
+		start := i + len("stream")
+		if i >= 3 && string(rest[i-3:i]) == "end" {
+			rest = rest[start:]
+			continue
+		}
+		if bytes.HasPrefix(rest[start:], []byte("\r\n")) {
+			start += 2
+		} else if bytes.HasPrefix(rest[start:], []byte("\n")) {
+			start++
+		} else {
+			rest = rest[start:]
+			continue
+		}
+		end := bytes.Index(rest[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		stream := rest[start : start+end]
+		rest = rest[start+end+len("endstream"):]
+
+		if isISDOC(stream) {
+			return bytes.TrimRight(stream, "\r\n"), nil
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(stream))
+		if err != nil {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(zr, maxExtractSize))
+		zr.Close()
+		if err != nil && len(content) == 0 {
+			continue
+		}
+		if isISDOC(content) {
+			return content, nil
+		}
+	}
+	return nil, fmt.Errorf("PDF contains no embedded ISDOC")
+}
@@ -0,0 +1,205 @@
+package isdoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Supplier is the issuer of an imported document.
+type Supplier struct {
+	Name           string `json:"name"`
+	RegistrationNo string `json:"registration_no,omitempty"`
+	VATNo          string `json:"vat_no,omitempty"`
+	Street         string `json:"street,omitempty"`
+	City           string `json:"city,omitempty"`
+	Zip            string `json:"zip,omitempty"`
+	Country        string `json:"country,omitempty"`
+	Email          string `json:"email,omitempty"`
+	Phone          string `json:"phone,omitempty"`
+}
+
+// ExpenseDraft is an ISDOC document mapped to a Fakturoid expense. Expense.SubjectID
+// is left empty for the caller to fill in.
+type ExpenseDraft struct {
+	Supplier Supplier `json:"supplier"`
+	// CustomerRegistrationNo and CustomerVATNo identify whom the document was issued to.
+	CustomerRegistrationNo string                         `json:"customer_registration_no,omitempty"`
+	CustomerVATNo          string                         `json:"customer_vat_no,omitempty"`
+	Expense                fakturoid.CreateExpenseRequest `json:"expense"`
+	// Total is the amount payable stated in the document, in the expense currency.
+	Total    fakturoid.Amount         `json:"total"`
+	Preview  fakturoid.InvoicePreview `json:"preview"`
+	Warnings []string                 `json:"warnings,omitempty"`
+}
+
+// ToExpense maps an ISDOC invoice received from a supplier to an expense request.
+// Line prices are taken without VAT; amounts are in the foreign currency when the
+// document has one.
+func ToExpense(doc *Invoice) (*ExpenseDraft, error) {
+	if len(doc.Lines) == 0 {
+		return nil, fmt.Errorf("document %s has no lines", doc.ID)
+	}
+
+	supplier := doc.Supplier.Party
+	customer := doc.Customer.Party
+	d := &ExpenseDraft{
+		Supplier: Supplier{
+			Name:           supplier.Name,
+			RegistrationNo: strings.TrimSpace(supplier.ID),
+			VATNo:          strings.ReplaceAll(supplier.VATNo(), " ", ""),
+			Street:         supplier.Address.Street(),
+			City:           supplier.Address.CityName,
+			Zip:            supplier.Address.PostalZone,
+			Country:        supplier.Address.Country.IdentificationCode,
+		},
+		CustomerRegistrationNo: strings.TrimSpace(customer.ID),
+		CustomerVATNo:          strings.ReplaceAll(customer.VATNo(), " ", ""),
+	}
+	if supplier.Contact != nil {
+		d.Supplier.Email = supplier.Contact.Email
+		d.Supplier.Phone = supplier.Contact.Telephone
+	}
+
+	foreign := doc.ForeignCurrencyCode != "" && !strings.EqualFold(doc.ForeignCurrencyCode, doc.LocalCurrencyCode)
+	// pick returns the amount in the expense currency.
+	pick := func(local, curr string) (fakturoid.Amount, error) {
+		if foreign && curr != "" {
+			return fakturoid.ParseAmount(curr)
+		}
+		return fakturoid.ParseAmount(local)
+	}
+
+	e := &d.Expense
+	e.OriginalNumber = doc.ID
+	e.DocumentType = "invoice"
+	e.IssuedOn = doc.IssueDate
+	e.TaxableFulfillmentDue = doc.TaxPointDate
+	e.Currency = strings.ToUpper(doc.LocalCurrencyCode)
+	e.VATPriceMode = fakturoid.VATPriceModeWithoutVAT
+	e.Description = doc.Note
+	if foreign {
+		e.Currency = strings.ToUpper(doc.ForeignCurrencyCode)
+	}
+	if foreign && doc.CurrRate != "" {
+		rate, err := exchangeRate(doc.CurrRate, doc.RefCurrRate)
+		if err != nil {
+			return nil, err
+		}
+		e.ExchangeRate = &rate
+	}
+
+	for i, l := range doc.Lines {
+		total, err := pick(l.LineExtensionAmount, l.LineExtensionAmountCurr)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: amount: %w", i+1, err)
+		}
+		vatRate, err := fakturoid.ParseAmount(firstNonEmpty(l.ClassifiedTaxCategory.Percent, "0"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: VAT rate: %w", i+1, err)
+		}
+		quantity := fakturoid.NewAmount(1)
+		unitName := ""
+		if l.InvoicedQuantity != nil && strings.TrimSpace(l.InvoicedQuantity.Value) != "" {
+			if quantity, err = fakturoid.ParseAmount(strings.TrimSpace(l.InvoicedQuantity.Value)); err != nil {
+				return nil, fmt.Errorf("line %d: quantity: %w", i+1, err)
+			}
+			unitName = l.InvoicedQuantity.UnitCode
+		}
+		if quantity.IsZero() {
+			quantity = fakturoid.NewAmount(1)
+		}
+		unitPrice, err := total.Div(quantity)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		name := l.Note
+		if l.Item != nil && l.Item.Description != "" {
+			name = l.Item.Description
+		}
+		e.Lines = append(e.Lines, fakturoid.ExpenseLine{
+			Name:      name,
+			Quantity:  quantity,
+			UnitName:  unitName,
+			UnitPrice: unitPrice.Round(4, fakturoid.RoundHalfUp),
+			VATRate:   vatRate,
+		})
+	}
+
+	if doc.PaymentMeans != nil && len(doc.PaymentMeans.Payments) > 0 {
+		p := doc.PaymentMeans.Payments[0]
+		e.PaymentMethod = paymentMethod(p.PaymentMeansCode)
+		if p.Details != nil {
+			e.DueOn = p.Details.PaymentDueDate
+			e.VariableSymbol = p.Details.VariableSymbol
+			e.IBAN = p.Details.IBAN
+			e.SwiftBIC = p.Details.BIC
+			if p.Details.ID != "" && p.Details.BankCode != "" {
+				e.BankAccount = p.Details.ID + "/" + p.Details.BankCode
+			}
+		}
+	}
+
+	total, err := pick(doc.LegalMonetaryTotal.PayableAmount, doc.LegalMonetaryTotal.PayableAmountCurr)
+	if err != nil {
+		return nil, fmt.Errorf("payable amount: %w", err)
+	}
+	d.Total = total
+
+	lines := make([]fakturoid.InvoiceLine, len(e.Lines))
+	for i, l := range e.Lines {
+		lines[i] = fakturoid.InvoiceLine{Name: l.Name, Quantity: l.Quantity, UnitName: l.UnitName, UnitPrice: l.UnitPrice, VATRate: l.VATRate}
+	}
+	d.Preview = fakturoid.PreviewInvoice(lines, fakturoid.PreviewOptions{
+		Currency:     e.Currency,
+		VATPriceMode: e.VATPriceMode,
+		VATPayer:     true,
+	})
+	d.Warnings = append(d.Warnings, d.Preview.Warnings...)
+
+	switch doc.DocumentType {
+	case TypeInvoice, TypeSimplified:
+	case TypeProforma:
+		d.Warnings = append(d.Warnings, "document is a proforma invoice, not a tax document")
+	case TypeCreditNote:
+		d.Warnings = append(d.Warnings, "document is a credit note; check the sign of the amounts")
+	default:
+		d.Warnings = append(d.Warnings, fmt.Sprintf("document type %d is imported as an ordinary invoice", doc.DocumentType))
+	}
+	paid, _ := fakturoid.ParseAmount(firstNonEmpty(doc.LegalMonetaryTotal.PaidDepositsAmount, "0"))
+	if !paid.IsZero() {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("document deducts paid deposits of %s %s", paid, doc.LocalCurrencyCode))
+	} else if diff := d.Preview.Total.Sub(total); diff.Abs().Cmp(fakturoid.MustParseAmount("0.01")) > 0 {
+		d.Warnings = append(d.Warnings, fmt.Sprintf("recalculated total %s differs from the document's payable amount %s", d.Preview.Total, total))
+	}
+	return d, nil
+}
+
+func exchangeRate(rate, ref string) (fakturoid.Amount, error) {
+	r, err := fakturoid.ParseAmount(firstNonEmpty(rate, "1"))
+	if err != nil {
+		return fakturoid.Amount{}, fmt.Errorf("exchange rate: %w", err)
+	}
+	q, err := fakturoid.ParseAmount(firstNonEmpty(ref, "1"))
+	if err != nil {
+		return fakturoid.Amount{}, fmt.Errorf("exchange rate: %w", err)
+	}
+	result, err := r.Div(q)
+	if err != nil {
+		return fakturoid.Amount{}, fmt.Errorf("exchange rate: %w", err)
+	}
+	return result, nil
+}
+
+func paymentMethod(code int) string {
+	switch code {
+	case PaymentCash:
+		return "cash"
+	case PaymentCard:
+		return "card"
+	case PaymentCOD:
+		return "cod"
+	}
+	return "bank"
+}
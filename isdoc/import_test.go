@@ -0,0 +1,180 @@
+package isdoc
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestToExpense(t *testing.T) {
+	doc, err := Unmarshal(readTestdata(t, "invoice.isdoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ToExpense(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Supplier{
+		Name:           "Example s.r.o.",
+		RegistrationNo: "01234567",
+		VATNo:          "CZ01234567",
+		Street:         "Dlouhá 12/3a",
+		City:           "Praha",
+		Zip:            "110 00",
+		Country:        "CZ",
+		Email:          "fakturace@example.com",
+	}
+	if d.Supplier != want {
+		t.Errorf("supplier = %+v, want %+v", d.Supplier, want)
+	}
+	if d.CustomerRegistrationNo != "87654321" || d.CustomerVATNo != "CZ87654321" {
+		t.Errorf("customer = %s, %s", d.CustomerRegistrationNo, d.CustomerVATNo)
+	}
+
+	e := d.Expense
+	if e.OriginalNumber != "2026-0042" || e.IssuedOn != "2026-03-02" || e.TaxableFulfillmentDue != "2026-02-28" || e.DueOn != "2026-03-16" {
+		t.Errorf("expense header = %+v", e)
+	}
+	if e.Currency != "CZK" || e.ExchangeRate != nil {
+		t.Errorf("currency = %s, rate %v; want CZK without a rate", e.Currency, e.ExchangeRate)
+	}
+	if e.PaymentMethod != "bank" || e.BankAccount != "1234567890/2010" || e.IBAN != "CZ6520100000001234567890" || e.VariableSymbol != "20260042" {
+		t.Errorf("payment = %s %s %s %s", e.PaymentMethod, e.BankAccount, e.IBAN, e.VariableSymbol)
+	}
+	if len(e.Lines) != 2 {
+		t.Fatalf("%d lines, want 2", len(e.Lines))
+	}
+	l := e.Lines[0]
+	if l.Name != "Konzultace" || l.Quantity.String() != "3" || l.UnitName != "h" || l.UnitPrice.String() != "1234.5" || l.VATRate.String() != "21" {
+		t.Errorf("line 1 = %+v", l)
+	}
+	if d.Total.String() != "4593" || d.Preview.Total.String() != "4593" {
+		t.Errorf("total = %s, recalculated %s; want 4593", d.Total, d.Preview.Total)
+	}
+	if len(d.Warnings) != 0 {
+		t.Errorf("warnings = %v", d.Warnings)
+	}
+}
+
+func TestToExpenseForeign(t *testing.T) {
+	doc, err := Unmarshal(readTestdata(t, "invoice_eur.isdoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ToExpense(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := d.Expense
+	if e.Currency != "EUR" || e.ExchangeRate == nil || e.ExchangeRate.String() != "25.125" {
+		t.Errorf("currency = %s, rate %v; want EUR at 25.125", e.Currency, e.ExchangeRate)
+	}
+	if e.Lines[0].UnitPrice.String() != "1234.5" {
+		t.Errorf("unit price = %s, want the EUR price 1234.5", e.Lines[0].UnitPrice)
+	}
+	if e.BankAccount != "" {
+		t.Errorf("bank account = %q from empty details", e.BankAccount)
+	}
+	if d.Total.String() != "4481.24" || len(d.Warnings) != 0 {
+		t.Errorf("total = %s, warnings %v; want 4481.24 and none", d.Total, d.Warnings)
+	}
+}
+
+func TestToExpenseWarnings(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Invoice)
+		want   string
+	}{
+		{"proforma", func(d *Invoice) { d.DocumentType = TypeProforma }, "proforma"},
+		{"credit note", func(d *Invoice) { d.DocumentType = TypeCreditNote }, "credit note"},
+		{"deposits", func(d *Invoice) { d.LegalMonetaryTotal.PaidDepositsAmount = "1000" }, "paid deposits of 1000"},
+		{"total", func(d *Invoice) { d.LegalMonetaryTotal.PayableAmount = "4600.00" }, "differs from the document's payable amount"},
+	}
+	for _, tt := range tests {
+		doc, err := Unmarshal(readTestdata(t, "invoice.isdoc"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tt.modify(doc)
+		d, err := ToExpense(doc)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !strings.Contains(strings.Join(d.Warnings, "\n"), tt.want) {
+			t.Errorf("%s: warnings = %v, want %q", tt.name, d.Warnings, tt.want)
+		}
+	}
+}
+
+func TestToExpenseErrors(t *testing.T) {
+	if _, err := ToExpense(&Invoice{ID: "1"}); err == nil {
+		t.Error("document without lines converted")
+	}
+	doc, err := Unmarshal(readTestdata(t, "invoice.isdoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Lines[1].LineExtensionAmount = "n/a"
+	if _, err := ToExpense(doc); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("invalid amount: error = %v", err)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	xml := readTestdata(t, "invoice.isdoc")
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	if w, err := zw.Create("invoice.ISDOC"); err != nil {
+		t.Fatal(err)
+	} else {
+		w.Write(xml)
+	}
+	zw.Close()
+
+	var compressed bytes.Buffer
+	fw := zlib.NewWriter(&compressed)
+	fw.Write(xml)
+	fw.Close()
+	pdf := []byte("%PDF-1.7\n1 0 obj\n<< /Length 3 >>\nstream\nabc\nendstream\nendobj\n2 0 obj\n<< /Type /EmbeddedFile /Filter /FlateDecode >>\nstream\n")
+	pdf = append(pdf, compressed.Bytes()...)
+	pdf = append(pdf, "\nendstream\nendobj\n%%EOF\n"...)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{"isdoc", xml, false},
+		{"isdocx", zipped.Bytes(), false},
+		{"pdf", pdf, false},
+		{"pdf without isdoc", []byte("%PDF-1.7\nstream\nabc\nendstream\n"), true},
+		{"other", []byte("<html></html>"), true},
+	}
+	for _, tt := range tests {
+		got, err := Extract(tt.data)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && !bytes.Equal(bytes.TrimSpace(got), bytes.TrimSpace(xml)) {
+			t.Errorf("%s: extracted %d bytes that differ from the document", tt.name, len(got))
+		}
+	}
+}
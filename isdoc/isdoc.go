@@ -0,0 +1,221 @@
+// Package isdoc reads and writes ISDOC 6, the Czech e-invoicing XML standard.
+package isdoc
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+const (
+	Namespace = "http://isdoc.cz/namespace/2013"
+	Version   = "6.0.2"
+)
+
+// Document types (element DocumentType).
+const (
+	TypeInvoice        = 1
+	TypeCreditNote     = 2
+	TypeDebitNote      = 3
+	TypeProforma       = 4
+	TypeAdvanceTaxDoc  = 5
+	TypeAdvanceCorrect = 6
+	TypeSimplified     = 7
+)
+
+// Payment means codes (element PaymentMeansCode).
+const (
+	PaymentCash     = 10
+	PaymentTransfer = 42
+	PaymentCard     = 48
+	PaymentCOD      = 50
+)
+
+// Invoice is the ISDOC root element. Field order follows the XSD sequence, which
+// encoding/xml preserves when marshalling. The root name is matched without namespace
+// so documents of older ISDOC versions can be read too.
+type Invoice struct {
+	XMLName                                 xml.Name      `xml:"Invoice"`
+	Xmlns                                   string        `xml:"xmlns,attr"`
+	Version                                 string        `xml:"version,attr"`
+	DocumentType                            int           `xml:"DocumentType"`
+	ID                                      string        `xml:"ID"`
+	UUID                                    string        `xml:"UUID"`
+	IssuingSystem                           string        `xml:"IssuingSystem,omitempty"`
+	IssueDate                               string        `xml:"IssueDate"`
+	TaxPointDate                            string        `xml:"TaxPointDate,omitempty"`
+	VATApplicable                           bool          `xml:"VATApplicable"`
+	ElectronicPossibilityAgreementReference string        `xml:"ElectronicPossibilityAgreementReference"`
+	Note                                    string        `xml:"Note,omitempty"`
+	LocalCurrencyCode                       string        `xml:"LocalCurrencyCode"`
+	ForeignCurrencyCode                     string        `xml:"ForeignCurrencyCode,omitempty"`
+	CurrRate                                string        `xml:"CurrRate"`
+	RefCurrRate                             string        `xml:"RefCurrRate"`
+	Supplier                                PartyWrapper  `xml:"AccountingSupplierParty"`
+	Customer                                PartyWrapper  `xml:"AccountingCustomerParty"`
+	Lines                                   []Line        `xml:"InvoiceLines>InvoiceLine"`
+	TaxTotal                                TaxTotal      `xml:"TaxTotal"`
+	LegalMonetaryTotal                      MonetaryTotal `xml:"LegalMonetaryTotal"`
+	PaymentMeans                            *PaymentMeans `xml:"PaymentMeans"`
+}
+
+type PartyWrapper struct {
+	Party Party `xml:"Party"`
+}
+
+type Party struct {
+	ID         string           `xml:"PartyIdentification>ID"`
+	Name       string           `xml:"PartyName>Name"`
+	Address    Address          `xml:"PostalAddress"`
+	TaxSchemes []PartyTaxScheme `xml:"PartyTaxScheme"`
+	Contact    *Contact         `xml:"Contact"`
+}
+
+// VATNo returns the party's VAT identification number, if any.
+func (p Party) VATNo() string {
+	for _, s := range p.TaxSchemes {
+		if s.TaxScheme == "VAT" || s.TaxScheme == "" {
+			return s.CompanyID
+		}
+	}
+	return ""
+}
+
+type Address struct {
+	StreetName     string  `xml:"StreetName"`
+	BuildingNumber string  `xml:"BuildingNumber"`
+	CityName       string  `xml:"CityName"`
+	PostalZone     string  `xml:"PostalZone"`
+	Country        Country `xml:"Country"`
+}
+
+// Street joins the street name and building number.
+func (a Address) Street() string {
+	if a.BuildingNumber == "" {
+		return a.StreetName
+	}
+	return a.StreetName + " " + a.BuildingNumber
+}
+
+type Country struct {
+	IdentificationCode string `xml:"IdentificationCode"`
+	Name               string `xml:"Name"`
+}
+
+type PartyTaxScheme struct {
+	CompanyID string `xml:"CompanyID"`
+	TaxScheme string `xml:"TaxScheme"`
+}
+
+type Contact struct {
+	Name      string `xml:"Name,omitempty"`
+	Telephone string `xml:"Telephone,omitempty"`
+	Email     string `xml:"ElectronicMail,omitempty"`
+}
+
+type Line struct {
+	ID                                  string      `xml:"ID"`
+	InvoicedQuantity                    *Quantity   `xml:"InvoicedQuantity"`
+	LineExtensionAmountCurr             string      `xml:"LineExtensionAmountCurr,omitempty"`
+	LineExtensionAmount                 string      `xml:"LineExtensionAmount"`
+	LineExtensionAmountTaxInclusiveCurr string      `xml:"LineExtensionAmountTaxInclusiveCurr,omitempty"`
+	LineExtensionAmountTaxInclusive     string      `xml:"LineExtensionAmountTaxInclusive"`
+	LineExtensionTaxAmount              string      `xml:"LineExtensionTaxAmount"`
+	UnitPrice                           string      `xml:"UnitPrice"`
+	UnitPriceTaxInclusive               string      `xml:"UnitPriceTaxInclusive"`
+	ClassifiedTaxCategory               TaxCategory `xml:"ClassifiedTaxCategory"`
+	Note                                string      `xml:"Note,omitempty"`
+	Item                                *Item       `xml:"Item"`
+}
+
+type Quantity struct {
+	UnitCode string `xml:"unitCode,attr,omitempty"`
+	Value    string `xml:",chardata"`
+}
+
+type TaxCategory struct {
+	Percent string `xml:"Percent"`
+	// VATCalculationMethod is 0 when VAT is calculated from the base, 1 from the total.
+	VATCalculationMethod *int `xml:"VATCalculationMethod"`
+}
+
+type Item struct {
+	Description string `xml:"Description"`
+}
+
+type TaxTotal struct {
+	SubTotals     []TaxSubTotal `xml:"TaxSubTotal"`
+	TaxAmountCurr string        `xml:"TaxAmountCurr,omitempty"`
+	TaxAmount     string        `xml:"TaxAmount"`
+}
+
+type TaxSubTotal struct {
+	TaxableAmountCurr                string      `xml:"TaxableAmountCurr,omitempty"`
+	TaxableAmount                    string      `xml:"TaxableAmount"`
+	TaxAmountCurr                    string      `xml:"TaxAmountCurr,omitempty"`
+	TaxAmount                        string      `xml:"TaxAmount"`
+	TaxInclusiveAmountCurr           string      `xml:"TaxInclusiveAmountCurr,omitempty"`
+	TaxInclusiveAmount               string      `xml:"TaxInclusiveAmount"`
+	AlreadyClaimedTaxableAmount      string      `xml:"AlreadyClaimedTaxableAmount"`
+	AlreadyClaimedTaxAmount          string      `xml:"AlreadyClaimedTaxAmount"`
+	AlreadyClaimedTaxInclusiveAmount string      `xml:"AlreadyClaimedTaxInclusiveAmount"`
+	DifferenceTaxableAmount          string      `xml:"DifferenceTaxableAmount"`
+	DifferenceTaxAmount              string      `xml:"DifferenceTaxAmount"`
+	DifferenceTaxInclusiveAmount     string      `xml:"DifferenceTaxInclusiveAmount"`
+	TaxCategory                      TaxCategory `xml:"TaxCategory"`
+}
+
+type MonetaryTotal struct {
+	TaxExclusiveAmount               string `xml:"TaxExclusiveAmount"`
+	TaxExclusiveAmountCurr           string `xml:"TaxExclusiveAmountCurr,omitempty"`
+	TaxInclusiveAmount               string `xml:"TaxInclusiveAmount"`
+	TaxInclusiveAmountCurr           string `xml:"TaxInclusiveAmountCurr,omitempty"`
+	AlreadyClaimedTaxExclusiveAmount string `xml:"AlreadyClaimedTaxExclusiveAmount"`
+	AlreadyClaimedTaxInclusiveAmount string `xml:"AlreadyClaimedTaxInclusiveAmount"`
+	DifferenceTaxExclusiveAmount     string `xml:"DifferenceTaxExclusiveAmount"`
+	DifferenceTaxExclusiveAmountCurr string `xml:"DifferenceTaxExclusiveAmountCurr,omitempty"`
+	DifferenceTaxInclusiveAmount     string `xml:"DifferenceTaxInclusiveAmount"`
+	DifferenceTaxInclusiveAmountCurr string `xml:"DifferenceTaxInclusiveAmountCurr,omitempty"`
+	PayableRoundingAmount            string `xml:"PayableRoundingAmount,omitempty"`
+	PayableRoundingAmountCurr        string `xml:"PayableRoundingAmountCurr,omitempty"`
+	PaidDepositsAmount               string `xml:"PaidDepositsAmount"`
+	PayableAmount                    string `xml:"PayableAmount"`
+	PayableAmountCurr                string `xml:"PayableAmountCurr,omitempty"`
+}
+
+type PaymentMeans struct {
+	Payments []Payment `xml:"Payment"`
+}
+
+type Payment struct {
+	PaidAmount       string          `xml:"PaidAmount"`
+	PaymentMeansCode int             `xml:"PaymentMeansCode"`
+	Details          *PaymentDetails `xml:"Details"`
+}
+
+type PaymentDetails struct {
+	PaymentDueDate string `xml:"PaymentDueDate"`
+	ID             string `xml:"ID"`
+	BankCode       string `xml:"BankCode"`
+	Name           string `xml:"Name"`
+	IBAN           string `xml:"IBAN"`
+	BIC            string `xml:"BIC"`
+	VariableSymbol string `xml:"VariableSymbol,omitempty"`
+}
+
+// Marshal renders the document with an XML declaration.
+func Marshal(inv *Invoice) ([]byte, error) {
+	data, err := xml.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal isdoc: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// Unmarshal parses an ISDOC document.
+func Unmarshal(data []byte) (*Invoice, error) {
+	var inv Invoice
+	if err := xml.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("parse isdoc: %w", err)
+	}
+	return &inv, nil
+}
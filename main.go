@@ -3,12 +3,22 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/company"
 	"github.com/tedyno/fakturoid-mcp/config"
+	"github.com/tedyno/fakturoid-mcp/emailtpl"
+	"github.com/tedyno/fakturoid-mcp/exrate"
 	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/reminder"
 	"github.com/tedyno/fakturoid-mcp/tools"
+	"github.com/tedyno/fakturoid-mcp/vatcheck"
+	"github.com/tedyno/fakturoid-mcp/webhook"
 )
 
 func main() {
@@ -19,14 +29,99 @@ func main() {
 	}
 
 	client := fakturoid.NewClient(cfg.ClientID, cfg.ClientSecret, cfg.Slug)
+	cacheTTL := time.Minute
+	if cfg.CacheTTL != "" {
+		cacheTTL, _ = time.ParseDuration(cfg.CacheTTL)
+	}
+	if cacheTTL > 0 {
+		client.SetCache(fakturoid.NewCache(cacheTTL, cfg.CacheDir))
+	}
 
 	s := server.NewMCPServer(
 		"fakturoid-mcp",
 		"1.1.0",
 		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
+		server.WithPromptCapabilities(false),
+		server.WithLogging(),
 	)
 
-	tools.RegisterAll(s, client)
+	ares := company.NewARES()
+	if cfg.ARESURL != "" {
+		ares.BaseURL = cfg.ARESURL
+	}
+	vies := vatcheck.NewVIES()
+	if cfg.VIESURL != "" {
+		vies.BaseURL = cfg.VIESURL
+	}
+	adis := vatcheck.NewADIS()
+	if cfg.ADISURL != "" {
+		adis.URL = cfg.ADISURL
+	}
+	cnb := exrate.NewCNB()
+	if cfg.CNBURL != "" {
+		cnb.URL = cfg.CNBURL
+	}
+	ratesCache := cfg.RatesCache
+	if dir, err := os.UserCacheDir(); ratesCache == "" && err == nil {
+		ratesCache = filepath.Join(dir, "fakturoid-mcp", "rates")
+	}
+	opts := tools.Options{
+		Companies:   ares,
+		VATCheckers: []vatcheck.Checker{vies, adis},
+		Rates:       exrate.NewCache(cnb, ratesCache),
+	}
+	if cfg.ReminderTemplates != "" {
+		templates, err := reminder.Load(cfg.ReminderTemplates)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.ReminderTemplates = templates
+	}
+	if cfg.EmailTemplates != "" {
+		templates, err := emailtpl.Load(cfg.EmailTemplates)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.EmailTemplates = templates
+	}
+	if cfg.WebhookListen != "" {
+		store, err := webhook.NewStore(cfg.WebhookStore)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.WebhookStore = store
+
+		receiver := webhook.NewReceiver(store, cfg.WebhookSecret, func(e webhook.Event) {
+			// The event reports a change made elsewhere, so cached responses may be stale.
+			client.InvalidateCache()
+			tools.NotifyWebhookEvent(s, e)
+		})
+		// Bind before serving MCP, so a busy port fails startup instead of leaving
+		// webhook tools without events.
+		listener, err := net.Listen("tcp", cfg.WebhookListen)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: webhook receiver: %v\n", err)
+			os.Exit(1)
+		}
+		srv := &http.Server{
+			Handler:           receiver,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		}
+		go func() {
+			if err := srv.Serve(listener); err != nil {
+				log.Printf("Webhook receiver error: %v", err)
+			}
+		}()
+	}
+
+	tools.RegisterAll(s, client, opts)
 
 	if err := server.ServeStdio(s); err != nil {
 		log.Fatalf("Server error: %v", err)
@@ -0,0 +1,67 @@
+package qrpay
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Limits from EPC069-12.
+const (
+	epcMaxName       = 70
+	epcMaxRemittance = 140
+)
+
+var epcMaxAmount = fakturoid.MustParseAmount("999999999.99")
+
+// EPC returns the EPC QR code payload (SEPA credit transfer, version 002) for p.
+// Only EUR payments are allowed.
+func EPC(p Payment) (string, error) {
+	if !strings.EqualFold(p.Currency, "EUR") {
+		return "", fmt.Errorf("EPC QR codes are for EUR payments only, not %s", p.Currency)
+	}
+	iban := normalizeIBAN(p.IBAN)
+	if iban == "" {
+		return "", fmt.Errorf("IBAN is required")
+	}
+	name := epcValue(p.Recipient, epcMaxName)
+	if name == "" {
+		return "", fmt.Errorf("recipient name is required")
+	}
+	amount := ""
+	if p.Amount.Sign() > 0 {
+		if p.Amount.Cmp(epcMaxAmount) > 0 {
+			return "", fmt.Errorf("amount %s exceeds the EPC maximum", p.Amount)
+		}
+		amount = "EUR" + p.Amount.StringFixed(2)
+	}
+
+	remittance := p.Message
+	if p.VariableSymbol != "" && !strings.Contains(remittance, p.VariableSymbol) {
+		remittance = strings.TrimSpace(remittance + " " + p.VariableSymbol)
+	}
+
+	lines := []string{
+		"BCD",
+		"002",
+		"1", // UTF-8
+		"SCT",
+		strings.ToUpper(strings.ReplaceAll(p.BIC, " ", "")),
+		name,
+		iban,
+		amount,
+		"", // purpose
+		"", // structured creditor reference
+		epcValue(remittance, epcMaxRemittance),
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func epcValue(v string, max int) string {
+	v = strings.Join(strings.Fields(v), " ")
+	if r := []rune(v); len(r) > max {
+		v = string(r[:max])
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package qrpay
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// CzechIBAN converts a Czech account number ("[prefix-]number/bank") to an IBAN.
+func CzechIBAN(account string) (string, error) {
+	account = strings.ReplaceAll(account, " ", "")
+	number, bank, ok := strings.Cut(account, "/")
+	if !ok || len(bank) != 4 || digits(bank) != bank {
+		return "", fmt.Errorf("invalid Czech account number %q", account)
+	}
+	prefix := ""
+	if p, n, found := strings.Cut(number, "-"); found {
+		prefix, number = p, n
+	}
+	if number == "" || len(number) > 10 || len(prefix) > 6 || digits(number) != number || digits(prefix) != prefix {
+		return "", fmt.Errorf("invalid Czech account number %q", account)
+	}
+
+	bban := bank + fmt.Sprintf("%06s", prefix) + fmt.Sprintf("%010s", number)
+	bban = strings.ReplaceAll(bban, " ", "0")
+	return "CZ" + checkDigits("CZ", bban) + bban, nil
+}
+
+// checkDigits computes the ISO 13616 check digits for a numeric BBAN.
+func checkDigits(country, bban string) string {
+	var b strings.Builder
+	b.WriteString(bban)
+	for _, r := range country {
+		fmt.Fprintf(&b, "%d", r-'A'+10)
+	}
+	b.WriteString("00")
+	n, _ := new(big.Int).SetString(b.String(), 10)
+	mod := new(big.Int).Mod(n, big.NewInt(97)).Int64()
+	return fmt.Sprintf("%02d", 98-mod)
+}
@@ -0,0 +1,43 @@
+package qrpay
+
+import (
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// Both SPAYD and EPC recommend error correction level M.
+const recoveryLevel = qrcode.Medium
+
+// PNG renders content as a PNG QR code of size×size pixels.
+func PNG(content string, size int) ([]byte, error) {
+	q, err := qrcode.New(content, recoveryLevel)
+	if err != nil {
+		return nil, fmt.Errorf("encode QR code: %w", err)
+	}
+	return q.PNG(size)
+}
+
+// SVG renders content as a scalable SVG QR code, one unit per module.
+func SVG(content string) ([]byte, error) {
+	q, err := qrcode.New(content, recoveryLevel)
+	if err != nil {
+		return nil, fmt.Errorf("encode QR code: %w", err)
+	}
+	bitmap := q.Bitmap()
+	n := len(bitmap)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,87 @@
+// Package qrpay builds QR payment codes: the Czech SPAYD (QR Platba) and the EPC/SEPA
+// credit transfer format.
+package qrpay
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Limits from the SPAYD specification.
+const (
+	spaydMaxMessage   = 60
+	spaydMaxRecipient = 35
+)
+
+// Payment describes a single credit transfer.
+type Payment struct {
+	IBAN           string
+	BIC            string
+	Amount         fakturoid.Amount
+	Currency       string
+	VariableSymbol string
+	DueDate        time.Time
+	Message        string
+	Recipient      string
+}
+
+// SPAYD returns the Short Payment Descriptor (SPD*1.0) for p.
+func SPAYD(p Payment) (string, error) {
+	iban := normalizeIBAN(p.IBAN)
+	if iban == "" {
+		return "", fmt.Errorf("IBAN is required")
+	}
+	acc := iban
+	if p.BIC != "" {
+		acc += "+" + strings.ToUpper(strings.ReplaceAll(p.BIC, " ", ""))
+	}
+
+	fields := []string{"SPD", "1.0", "ACC:" + acc}
+	if p.Amount.Sign() > 0 {
+		fields = append(fields, "AM:"+p.Amount.StringFixed(2))
+	}
+	if p.Currency != "" {
+		fields = append(fields, "CC:"+strings.ToUpper(p.Currency))
+	}
+	if !p.DueDate.IsZero() {
+		fields = append(fields, "DT:"+p.DueDate.Format("20060102"))
+	}
+	if p.Message != "" {
+		fields = append(fields, "MSG:"+spaydValue(p.Message, spaydMaxMessage))
+	}
+	if p.Recipient != "" {
+		fields = append(fields, "RN:"+spaydValue(p.Recipient, spaydMaxRecipient))
+	}
+	if vs := digits(p.VariableSymbol); vs != "" {
+		if len(vs) > 10 {
+			return "", fmt.Errorf("variable symbol %q is longer than 10 digits", p.VariableSymbol)
+		}
+		fields = append(fields, "X-VS:"+vs)
+	}
+	return strings.Join(fields, "*"), nil
+}
+
+// spaydValue truncates v and escapes the field separator.
+func spaydValue(v string, max int) string {
+	v = strings.TrimSpace(v)
+	if r := []rune(v); len(r) > max {
+		v = string(r[:max])
+	}
+	return strings.ReplaceAll(v, "*", "%2A")
+}
+
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+func normalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.ReplaceAll(iban, " ", ""))
+}
@@ -0,0 +1,86 @@
+package reminder
+
+import "github.com/tedyno/fakturoid-mcp/emailtpl"
+
+// Defaults are the built-in templates: a friendly reminder, a second reminder after
+// 15 days and a final notice after 31 days.
+var Defaults = Templates{
+	Czech: {
+		{MinDays: 1, Template: emailtpl.Template{
+			Subject: "Připomínka splatnosti faktury {{.Number}}",
+			Body: `Dobrý den,
+
+dovolujeme si Vás upozornit, že faktura {{.Number}} vystavená {{.IssuedOn}} byla splatná {{.DueOn}} a zatím jsme neobdrželi její úhradu.
+
+{{.PaymentDetails}}
+
+Pokud jste fakturu již uhradili, považujte prosím tuto zprávu za bezpředmětnou.
+
+S pozdravem
+{{.Sender}}`,
+		}},
+		{MinDays: 15, Template: emailtpl.Template{
+			Subject: "Upomínka: faktura {{.Number}} je {{.DaysOverdue}} dní po splatnosti",
+			Body: `Dobrý den,
+
+faktura {{.Number}} se splatností {{.DueOn}} je již {{.DaysOverdue}} dní po splatnosti. Žádáme Vás o její úhradu nejpozději do 7 dnů.
+
+{{.PaymentDetails}}
+
+Pokud jste fakturu mezitím uhradili, děkujeme a tuto zprávu prosím ignorujte.
+
+S pozdravem
+{{.Sender}}`,
+		}},
+		{MinDays: 31, Template: emailtpl.Template{
+			Subject: "Poslední upomínka: faktura {{.Number}}",
+			Body: `Dobrý den,
+
+přes předchozí upomínky zůstává faktura {{.Number}} se splatností {{.DueOn}} neuhrazena již {{.DaysOverdue}} dní. Pokud částku neuhradíte do 7 dnů, budeme nuceni přistoupit k jejímu vymáhání.
+
+{{.PaymentDetails}}
+
+S pozdravem
+{{.Sender}}`,
+		}},
+	},
+	English: {
+		{MinDays: 1, Template: emailtpl.Template{
+			Subject: "Payment reminder: invoice {{.Number}}",
+			Body: `Hello,
+
+this is a friendly reminder that invoice {{.Number}} issued on {{.IssuedOn}} was due on {{.DueOn}} and we have not received the payment yet.
+
+{{.PaymentDetails}}
+
+If you have already paid, please disregard this message.
+
+Kind regards
+{{.Sender}}`,
+		}},
+		{MinDays: 15, Template: emailtpl.Template{
+			Subject: "Second reminder: invoice {{.Number}} is {{.DaysOverdue}} days overdue",
+			Body: `Hello,
+
+invoice {{.Number}} due on {{.DueOn}} is now {{.DaysOverdue}} days overdue. Please settle it within 7 days.
+
+{{.PaymentDetails}}
+
+If the payment is already on its way, thank you and please disregard this message.
+
+Kind regards
+{{.Sender}}`,
+		}},
+		{MinDays: 31, Template: emailtpl.Template{
+			Subject: "Final notice: invoice {{.Number}}",
+			Body: `Hello,
+
+despite our previous reminders, invoice {{.Number}} due on {{.DueOn}} has been unpaid for {{.DaysOverdue}} days. If it is not paid within 7 days, we will have to pursue collection.
+
+{{.PaymentDetails}}
+
+Kind regards
+{{.Sender}}`,
+		}},
+	},
+}
@@ -0,0 +1,178 @@
+// Package reminder renders payment reminders for overdue invoices.
+package reminder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/emailtpl"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Languages with built-in templates.
+const (
+	Czech   = emailtpl.Czech
+	English = emailtpl.English
+)
+
+// Template is one escalation level. It applies from MinDays past due until the next
+// level's MinDays. Subject and Body are text/template strings over Data.
+type Template struct {
+	MinDays int `json:"min_days"`
+	emailtpl.Template
+}
+
+// Templates maps a language to its escalation levels.
+type Templates map[string][]Template
+
+// Data is what templates can use. Amounts and dates are already formatted for the
+// reminder's language.
+type Data struct {
+	Number         string
+	ClientName     string
+	IssuedOn       string
+	DueOn          string
+	DaysOverdue    int
+	Amount         string
+	VariableSymbol string
+	BankAccount    string
+	IBAN           string
+	// Link is the invoice's public page, which shows the payment QR code.
+	Link string
+	// PaymentDetails is a ready-made block with the amount, account, symbol and link.
+	PaymentDetails string
+	Sender         string
+}
+
+// Invoice is the input for a reminder.
+type Invoice struct {
+	Invoice     fakturoid.Invoice
+	DaysOverdue int
+	Amount      fakturoid.Money
+	BankAccount string
+	IBAN        string
+	Sender      string
+}
+
+type Email struct {
+	Language string `json:"language"`
+	Level    int    `json:"level"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+}
+
+// Load reads templates from a JSON file shaped like Templates. Languages in the file
+// replace the built-in ones; others keep the defaults.
+func Load(path string) (Templates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read reminder templates: %w", err)
+	}
+	var custom Templates
+	if err := json.Unmarshal(data, &custom); err != nil {
+		return nil, fmt.Errorf("parse reminder templates: %w", err)
+	}
+
+	result := Templates{}
+	for lang, levels := range Defaults {
+		result[lang] = levels
+	}
+	for lang, levels := range custom {
+		if len(levels) == 0 {
+			return nil, fmt.Errorf("reminder templates for %q have no levels", lang)
+		}
+		levels = append([]Template(nil), levels...)
+		sort.SliceStable(levels, func(i, j int) bool { return levels[i].MinDays < levels[j].MinDays })
+		for i, t := range levels {
+			if _, _, err := emailtpl.Execute(t.Template, Data{}); err != nil {
+				return nil, fmt.Errorf("reminder template %s level %d: %w", lang, i+1, err)
+			}
+		}
+		result[strings.ToLower(lang)] = levels
+	}
+	return result, nil
+}
+
+// Language picks the reminder language for an invoice: Czech for invoices in Czech or
+// Slovak, English otherwise.
+func Language(inv fakturoid.Invoice) string {
+	return emailtpl.Language(inv)
+}
+
+// Level returns the 1-based escalation level for days past due.
+func (t Templates) Level(lang string, daysOverdue int) int {
+	level := 1
+	for i, tpl := range t[lang] {
+		if daysOverdue >= tpl.MinDays {
+			level = i + 1
+		}
+	}
+	return level
+}
+
+// Render produces the reminder in lang at level (0 picks the level by days past due).
+func (t Templates) Render(lang string, level int, in Invoice) (Email, error) {
+	levels, ok := t[lang]
+	if !ok || len(levels) == 0 {
+		return Email{}, fmt.Errorf("no reminder templates for language %q", lang)
+	}
+	if level == 0 {
+		level = t.Level(lang, in.DaysOverdue)
+	}
+	if level < 1 || level > len(levels) {
+		return Email{}, fmt.Errorf("level must be between 1 and %d", len(levels))
+	}
+
+	subject, body, err := emailtpl.Execute(levels[level-1].Template, newData(lang, in))
+	if err != nil {
+		return Email{}, err
+	}
+	return Email{Language: lang, Level: level, Subject: subject, Body: body}, nil
+}
+
+func newData(lang string, in Invoice) Data {
+	inv := in.Invoice
+	d := Data{
+		Number:         inv.Number,
+		ClientName:     inv.ClientName,
+		IssuedOn:       emailtpl.FormatDate(lang, inv.IssuedOn),
+		DueOn:          emailtpl.FormatDate(lang, inv.DueOn),
+		DaysOverdue:    in.DaysOverdue,
+		Amount:         emailtpl.FormatMoney(lang, in.Amount),
+		VariableSymbol: inv.VariableSymbol,
+		BankAccount:    in.BankAccount,
+		IBAN:           in.IBAN,
+		Link:           inv.PublicHTMLURL,
+		Sender:         in.Sender,
+	}
+	if d.ClientName == "" {
+		d.ClientName = inv.SubjectName
+	}
+
+	labels := paymentLabels[lang]
+	if labels == nil {
+		labels = paymentLabels[English]
+	}
+	var b strings.Builder
+	line := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%s: %s\n", label, value)
+		}
+	}
+	line(labels[0], d.Amount)
+	line(labels[1], d.BankAccount)
+	line("IBAN", d.IBAN)
+	line(labels[2], d.VariableSymbol)
+	line(labels[3], d.Link)
+	d.PaymentDetails = strings.TrimSpace(b.String())
+	return d
+}
+
+// paymentLabels are the labels of Data.PaymentDetails: amount, account, symbol, link.
+var paymentLabels = map[string][]string{
+	Czech:   {"Částka k úhradě", "Číslo účtu", "Variabilní symbol", "Faktura a QR kód pro platbu"},
+	English: {"Amount due", "Account number", "Variable symbol", "Invoice and payment QR code"},
+}
@@ -0,0 +1,214 @@
+// Package report aggregates Fakturoid documents into summaries for the assistant.
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+const dateLayout = "2006-01-02"
+
+// AgingBuckets holds amounts split by how long they are past due.
+type AgingBuckets struct {
+	Current    fakturoid.Amount `json:"current"`
+	Days1To30  fakturoid.Amount `json:"days_1_30"`
+	Days31To60 fakturoid.Amount `json:"days_31_60"`
+	Days61To90 fakturoid.Amount `json:"days_61_90"`
+	Over90     fakturoid.Amount `json:"days_over_90"`
+	Total      fakturoid.Amount `json:"total"`
+}
+
+// agingBucketLabels are in the same order as AgingBuckets.values.
+var agingBucketLabels = []string{"Current", "1–30", "31–60", "61–90", "90+"}
+
+func (b *AgingBuckets) add(daysOverdue int, a fakturoid.Amount) {
+	switch {
+	case daysOverdue <= 0:
+		b.Current = b.Current.Add(a)
+	case daysOverdue <= 30:
+		b.Days1To30 = b.Days1To30.Add(a)
+	case daysOverdue <= 60:
+		b.Days31To60 = b.Days31To60.Add(a)
+	case daysOverdue <= 90:
+		b.Days61To90 = b.Days61To90.Add(a)
+	default:
+		b.Over90 = b.Over90.Add(a)
+	}
+	b.Total = b.Total.Add(a)
+}
+
+func (b AgingBuckets) values() []fakturoid.Amount {
+	return []fakturoid.Amount{b.Current, b.Days1To30, b.Days31To60, b.Days61To90, b.Over90, b.Total}
+}
+
+func agingBucketName(daysOverdue int) string {
+	switch {
+	case daysOverdue <= 0:
+		return "current"
+	case daysOverdue <= 30:
+		return "days_1_30"
+	case daysOverdue <= 60:
+		return "days_31_60"
+	case daysOverdue <= 90:
+		return "days_61_90"
+	}
+	return "days_over_90"
+}
+
+type AgingInvoice struct {
+	ID          int              `json:"id"`
+	Number      string           `json:"number"`
+	SubjectID   int              `json:"subject_id"`
+	SubjectName string           `json:"subject_name"`
+	DueOn       string           `json:"due_on"`
+	DaysOverdue int              `json:"days_overdue"`
+	Bucket      string           `json:"bucket"`
+	Remaining   fakturoid.Money  `json:"remaining"`
+	Native      fakturoid.Amount `json:"remaining_native"`
+	Converted   bool             `json:"converted"`
+}
+
+type AgingSubject struct {
+	SubjectID   int          `json:"subject_id"`
+	SubjectName string       `json:"subject_name"`
+	Invoices    int          `json:"invoices"`
+	Buckets     AgingBuckets `json:"buckets"`
+}
+
+type AgingReport struct {
+	AsOf     string         `json:"as_of"`
+	Currency string         `json:"currency"`
+	Subjects []AgingSubject `json:"subjects"`
+	Totals   AgingBuckets   `json:"totals"`
+	Invoices []AgingInvoice `json:"invoices"`
+	// Unconverted lists foreign-currency invoices that could not be expressed in Currency;
+	// they are not included in the subject and total buckets.
+	Unconverted []AgingInvoice `json:"unconverted,omitempty"`
+}
+
+// Aging groups the unpaid remainder of invoices by subject and by days past DueOn,
+// in the account currency.
+func Aging(invoices []fakturoid.Invoice, accountCurrency string, asOf time.Time) AgingReport {
+	asOf = truncateDay(asOf)
+	rep := AgingReport{
+		AsOf:     asOf.Format(dateLayout),
+		Currency: strings.ToUpper(accountCurrency),
+		Subjects: []AgingSubject{},
+		Invoices: []AgingInvoice{},
+	}
+	bySubject := map[int]*AgingSubject{}
+
+	for _, inv := range invoices {
+		if inv.RemainingAmount.Sign() <= 0 {
+			continue
+		}
+		days := DaysOverdue(inv.DueOn, asOf)
+		native, ok := inv.RemainingNative(accountCurrency)
+		item := AgingInvoice{
+			ID:          inv.ID,
+			Number:      inv.Number,
+			SubjectID:   inv.SubjectID,
+			SubjectName: SubjectName(inv),
+			DueOn:       inv.DueOn,
+			DaysOverdue: days,
+			Bucket:      agingBucketName(days),
+			Remaining:   inv.RemainingMoney(),
+			Native:      native,
+			Converted:   ok,
+		}
+		if !ok {
+			rep.Unconverted = append(rep.Unconverted, item)
+			continue
+		}
+		rep.Invoices = append(rep.Invoices, item)
+
+		subj, found := bySubject[inv.SubjectID]
+		if !found {
+			subj = &AgingSubject{SubjectID: inv.SubjectID, SubjectName: item.SubjectName}
+			bySubject[inv.SubjectID] = subj
+		}
+		subj.Invoices++
+		subj.Buckets.add(days, native)
+		rep.Totals.add(days, native)
+	}
+
+	for _, subj := range bySubject {
+		rep.Subjects = append(rep.Subjects, *subj)
+	}
+	// Biggest debtors first.
+	sort.Slice(rep.Subjects, func(i, j int) bool {
+		if c := rep.Subjects[i].Buckets.Total.Cmp(rep.Subjects[j].Buckets.Total); c != 0 {
+			return c > 0
+		}
+		return rep.Subjects[i].SubjectName < rep.Subjects[j].SubjectName
+	})
+	sort.Slice(rep.Invoices, func(i, j int) bool { return rep.Invoices[i].DaysOverdue > rep.Invoices[j].DaysOverdue })
+	return rep
+}
+
+// Table renders the report as a Markdown table.
+func (rep AgingReport) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Receivables aging as of %s (%s)\n\n", rep.AsOf, rep.Currency)
+	fmt.Fprintf(&b, "| Subject | %s | Total |\n", strings.Join(agingBucketLabels, " | "))
+	b.WriteString("|---" + strings.Repeat("|---:", len(agingBucketLabels)+1) + "|\n")
+
+	row := func(name string, buckets AgingBuckets) {
+		cells := []string{name}
+		for _, v := range buckets.values() {
+			cells = append(cells, v.StringFixed(2))
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	for _, s := range rep.Subjects {
+		row(s.SubjectName, s.Buckets)
+	}
+	row("**Total**", rep.Totals)
+
+	if len(rep.Unconverted) > 0 {
+		b.WriteString("\nNot converted to " + rep.Currency + ":\n")
+		for _, inv := range rep.Unconverted {
+			fmt.Fprintf(&b, "- %s %s: %s, due %s\n", inv.Number, inv.SubjectName, inv.Remaining, inv.DueOn)
+		}
+	}
+	return b.String()
+}
+
+// DaysOverdue returns how many days dueOn (YYYY-MM-DD) lies before asOf; 0 or less means not yet due.
+// An unparseable date counts as not due.
+func DaysOverdue(dueOn string, asOf time.Time) int {
+	due, err := time.ParseInLocation(dateLayout, dueOn, asOf.Location())
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(truncateDay(asOf).Sub(due).Hours() / 24))
+}
+
+// SubjectName returns the best available name of the invoice's subject.
+func SubjectName(inv fakturoid.Invoice) string {
+	switch {
+	case inv.ClientName != "":
+		return inv.ClientName
+	case inv.SubjectName != "":
+		return inv.SubjectName
+	}
+	return fmt.Sprintf("subject #%d", inv.SubjectID)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// dayIn parses a document date and reports whether it falls within [from, to].
+func dayIn(date string, from, to time.Time) (time.Time, bool) {
+	day, err := time.ParseInLocation(dateLayout, date, from.Location())
+	if err != nil || day.Before(truncateDay(from)) || day.After(truncateDay(to)) {
+		return day, false
+	}
+	return day, true
+}
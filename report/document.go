@@ -0,0 +1,153 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// document is the common view of an invoice or an expense used for aggregation.
+type document struct {
+	expense  bool
+	id       int
+	number   string
+	date     string
+	subject  string
+	tags     []string
+	currency string
+	total    fakturoid.Amount
+	factor   fakturoid.Amount
+	net      fakturoid.Amount
+	gross    fakturoid.Amount
+	lines    []fakturoid.InvoiceLine
+	vatMode  string
+	// vatNo is the counterparty's VAT number; reference is the document number
+	// the counterparty knows (ours for invoices, the supplier's for expenses).
+	vatNo     string
+	reference string
+}
+
+// invoiceDocument builds the aggregation view of an invoice dated by date.
+func invoiceDocument(inv fakturoid.Invoice, date, accountCurrency string) document {
+	return document{
+		id:        inv.ID,
+		number:    inv.Number,
+		date:      date,
+		subject:   SubjectName(inv),
+		tags:      inv.Tags,
+		currency:  inv.Currency,
+		total:     inv.Total,
+		factor:    nativeFactor(inv.Currency, accountCurrency, inv.ExchangeRate, inv.Total, inv.NativeTotal),
+		net:       inv.NativeSubtotal,
+		gross:     inv.NativeTotal,
+		lines:     inv.Lines,
+		vatMode:   inv.VATPriceMode,
+		vatNo:     inv.ClientVATNo,
+		reference: inv.Number,
+	}
+}
+
+// expenseDocument builds the aggregation view of an expense dated by date.
+func expenseDocument(exp fakturoid.Expense, date, accountCurrency string) document {
+	reference := exp.OriginalNumber
+	if reference == "" {
+		reference = exp.Number
+	}
+	return document{
+		expense:   true,
+		id:        exp.ID,
+		number:    exp.Number,
+		date:      date,
+		subject:   expenseSubjectName(exp),
+		tags:      exp.Tags,
+		currency:  exp.Currency,
+		total:     exp.Total,
+		factor:    nativeFactor(exp.Currency, accountCurrency, exp.ExchangeRate, exp.Total, exp.NativeTotal),
+		net:       exp.NativeSubtotal,
+		gross:     exp.NativeTotal,
+		lines:     expenseLines(exp.Lines),
+		vatMode:   exp.VATPriceMode,
+		vatNo:     exp.SupplierVATNo,
+		reference: reference,
+	}
+}
+
+// nativeFactor returns the multiplier from document currency to account currency,
+// or zero when it cannot be determined.
+func nativeFactor(currency, accountCurrency string, rate, total, nativeTotal fakturoid.Amount) fakturoid.Amount {
+	switch {
+	case currency == "" || strings.EqualFold(currency, accountCurrency):
+		return fakturoid.NewAmount(1)
+	case !rate.IsZero():
+		return rate
+	case !total.IsZero() && !nativeTotal.IsZero():
+		f, _ := nativeTotal.Div(total)
+		return f
+	case total.IsZero():
+		return fakturoid.NewAmount(1)
+	}
+	return fakturoid.Amount{}
+}
+
+// fillNative derives native amounts the API did not provide.
+func (d *document) fillNative(vatRates []fakturoid.VATRateSummary) {
+	if d.gross.IsZero() {
+		d.gross = d.total.Mul(d.factor).Round(2, fakturoid.RoundHalfUp)
+	}
+	if d.net.IsZero() {
+		net := fakturoid.Amount{}
+		for _, s := range vatRates {
+			net = net.Add(s.Base)
+		}
+		if net.IsZero() {
+			net = d.gross
+		}
+		d.net = net
+	}
+}
+
+// vatRates recomputes the document's VAT breakdown from its lines, in account currency.
+func (d *document) vatRates() []fakturoid.VATRateSummary {
+	p := fakturoid.PreviewInvoice(d.lines, fakturoid.PreviewOptions{
+		Currency:     d.currency,
+		VATPriceMode: d.vatMode,
+		VATPayer:     true,
+	})
+	for i := range p.VATRates {
+		p.VATRates[i].Base = p.VATRates[i].Base.Mul(d.factor).Round(2, fakturoid.RoundHalfUp)
+		p.VATRates[i].VAT = p.VATRates[i].VAT.Mul(d.factor).Round(2, fakturoid.RoundHalfUp)
+	}
+	return p.VATRates
+}
+
+func (d *document) skipped(reason string) SkippedDocument {
+	typ := "invoice"
+	if d.expense {
+		typ = "expense"
+	}
+	return SkippedDocument{Type: typ, ID: d.id, Number: d.number, Total: fakturoid.NewMoney(d.total, d.currency), Reason: reason}
+}
+
+// IsProforma reports whether the invoice is a proforma, which is not a tax document.
+func IsProforma(inv fakturoid.Invoice) bool {
+	return inv.DocumentType == "proforma" || inv.DocumentType == "partial_proforma"
+}
+
+func expenseSubjectName(exp fakturoid.Expense) string {
+	switch {
+	case exp.SupplierName != "":
+		return exp.SupplierName
+	case exp.SubjectName != "":
+		return exp.SubjectName
+	}
+	return fmt.Sprintf("subject #%d", exp.SubjectID)
+}
+
+func expenseLines(lines []fakturoid.ExpenseLine) []fakturoid.InvoiceLine {
+	result := make([]fakturoid.InvoiceLine, len(lines))
+	for i, l := range lines {
+		result[i] = fakturoid.InvoiceLine{Name: l.Name, Quantity: l.Quantity, UnitName: l.UnitName, UnitPrice: l.UnitPrice, VATRate: l.VATRate}
+	}
+	return result
+}
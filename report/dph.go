@@ -0,0 +1,375 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// ControlStatementLimit is the document value including VAT (CZK) above which supplies
+// between VAT payers are reported one by one in sections A.4 and B.2.
+var ControlStatementLimit = fakturoid.NewAmount(10000)
+
+// VATPeriod is a Czech VAT period: a calendar month or, when Month is 0, a quarter.
+type VATPeriod struct {
+	Year    int
+	Month   int
+	Quarter int
+}
+
+func (p VATPeriod) Validate() error {
+	switch {
+	case p.Year < 2000:
+		return fmt.Errorf("invalid year %d", p.Year)
+	case p.Month == 0 && p.Quarter == 0:
+		return fmt.Errorf("month or quarter is required")
+	case p.Month != 0 && p.Quarter != 0:
+		return fmt.Errorf("use either month or quarter, not both")
+	case p.Month < 0 || p.Month > 12:
+		return fmt.Errorf("invalid month %d", p.Month)
+	case p.Quarter < 0 || p.Quarter > 4:
+		return fmt.Errorf("invalid quarter %d", p.Quarter)
+	}
+	return nil
+}
+
+// Bounds returns the first and last day of the period.
+func (p VATPeriod) Bounds() (time.Time, time.Time) {
+	first := time.Month(p.Month)
+	months := 1
+	if p.Month == 0 {
+		first = time.Month((p.Quarter-1)*3 + 1)
+		months = 3
+	}
+	from := time.Date(p.Year, first, 1, 0, 0, 0, 0, time.Local)
+	return from, from.AddDate(0, months, -1)
+}
+
+// Invoices returns the invoices whose taxable supply falls into the period.
+func (p VATPeriod) Invoices(invoices []fakturoid.Invoice) []fakturoid.Invoice {
+	from, to := p.Bounds()
+	var in []fakturoid.Invoice
+	for _, inv := range invoices {
+		if _, ok := dayIn(vatInvoiceDate(inv), from, to); ok {
+			in = append(in, inv)
+		}
+	}
+	return in
+}
+
+// Expenses is Invoices for expenses.
+func (p VATPeriod) Expenses(expenses []fakturoid.Expense) []fakturoid.Expense {
+	from, to := p.Bounds()
+	var in []fakturoid.Expense
+	for _, exp := range expenses {
+		if _, ok := dayIn(vatExpenseDate(exp), from, to); ok {
+			in = append(in, exp)
+		}
+	}
+	return in
+}
+
+func vatInvoiceDate(inv fakturoid.Invoice) string {
+	return firstDate(inv.TaxableFulfillmentDue, inv.IssuedOn)
+}
+
+func vatExpenseDate(exp fakturoid.Expense) string {
+	return firstDate(exp.TaxableFulfillmentDue, exp.IssuedOn)
+}
+
+func (p VATPeriod) String() string {
+	if p.Month == 0 {
+		return fmt.Sprintf("%d-Q%d", p.Year, p.Quarter)
+	}
+	return fmt.Sprintf("%d-%02d", p.Year, p.Month)
+}
+
+// VATBase is a tax base with the VAT calculated from it.
+type VATBase struct {
+	Base fakturoid.Amount `json:"base"`
+	VAT  fakturoid.Amount `json:"vat"`
+}
+
+func (b *VATBase) add(s fakturoid.VATRateSummary) {
+	b.Base = b.Base.Add(s.Base)
+	b.VAT = b.VAT.Add(s.VAT)
+}
+
+// KHRates are bases and VAT split into the control statement's rate columns:
+// 1 = basic rate, 2 = first reduced rate, 3 = second reduced rate (used until 2023).
+type KHRates struct {
+	Base1 fakturoid.Amount `json:"zakl_dane1"`
+	VAT1  fakturoid.Amount `json:"dan1"`
+	Base2 fakturoid.Amount `json:"zakl_dane2"`
+	VAT2  fakturoid.Amount `json:"dan2"`
+	Base3 fakturoid.Amount `json:"zakl_dane3"`
+	VAT3  fakturoid.Amount `json:"dan3"`
+}
+
+func (k *KHRates) add(slot int, s fakturoid.VATRateSummary) {
+	switch slot {
+	case 1:
+		k.Base1, k.VAT1 = k.Base1.Add(s.Base), k.VAT1.Add(s.VAT)
+	case 2:
+		k.Base2, k.VAT2 = k.Base2.Add(s.Base), k.VAT2.Add(s.VAT)
+	case 3:
+		k.Base3, k.VAT3 = k.Base3.Add(s.Base), k.VAT3.Add(s.VAT)
+	}
+}
+
+// KHRecord is a single document reported in section A.4 or B.2.
+type KHRecord struct {
+	DocumentID int    `json:"document_id"`
+	Number     string `json:"number"`
+	Subject    string `json:"subject"`
+	// VATNo is the counterparty's DIČ, Reference the evidence number of the tax document
+	// and Date the date of the taxable supply (DPPD).
+	VATNo     string `json:"vat_no"`
+	Reference string `json:"reference"`
+	Date      string `json:"date"`
+	KHRates
+}
+
+type ControlStatement struct {
+	A4 []KHRecord `json:"a4"`
+	A5 KHRates    `json:"a5"`
+	B2 []KHRecord `json:"b2"`
+	B3 KHRates    `json:"b3"`
+}
+
+// VATReturn holds the data for the Czech VAT return (přiznání k DPH) and control
+// statement (kontrolní hlášení) of one period, in CZK.
+type VATReturn struct {
+	Period string `json:"period"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	// Rows of the VAT return form: 1 and 2 (output), 40 and 41 (input).
+	OutputBasic   VATBase `json:"row_1_output_basic"`
+	OutputReduced VATBase `json:"row_2_output_reduced"`
+	InputBasic    VATBase `json:"row_40_input_basic"`
+	InputReduced  VATBase `json:"row_41_input_reduced"`
+	// OutputVAT is row 62, InputVAT rows 46/63; TaxDue (row 64) and ExcessDeduction (row 65)
+	// are the resulting liability or refund.
+	OutputVAT        fakturoid.Amount  `json:"row_62_output_vat"`
+	InputVAT         fakturoid.Amount  `json:"row_63_input_vat"`
+	TaxDue           fakturoid.Amount  `json:"row_64_tax_due"`
+	ExcessDeduction  fakturoid.Amount  `json:"row_65_excess_deduction"`
+	ControlStatement ControlStatement  `json:"control_statement"`
+	Skipped          []SkippedDocument `json:"skipped,omitempty"`
+
+	period VATPeriod
+}
+
+// CzechVAT computes output VAT from invoices (by taxable fulfillment date) and input VAT from
+// expenses for the period. Only domestic supplies with Czech VAT are covered; reverse-charge
+// and EU supplies are listed in Skipped.
+func CzechVAT(invoices []fakturoid.Invoice, expenses []fakturoid.Expense, period VATPeriod) VATReturn {
+	from, to := period.Bounds()
+	rep := VATReturn{
+		Period: period.String(),
+		From:   from.Format(dateLayout),
+		To:     to.Format(dateLayout),
+		ControlStatement: ControlStatement{
+			A4: []KHRecord{},
+			B2: []KHRecord{},
+		},
+		period: period,
+	}
+
+	var docs []document
+	for _, inv := range invoices {
+		if inv.Status == "cancelled" || IsProforma(inv) {
+			continue
+		}
+		docs = append(docs, invoiceDocument(inv, vatInvoiceDate(inv), "CZK"))
+	}
+	for _, exp := range expenses {
+		docs = append(docs, expenseDocument(exp, vatExpenseDate(exp), "CZK"))
+	}
+
+	for _, d := range docs {
+		if _, ok := dayIn(d.date, from, to); !ok {
+			continue
+		}
+		if d.factor.IsZero() {
+			rep.Skipped = append(rep.Skipped, d.skipped("no exchange rate to CZK"))
+			continue
+		}
+		rates := d.vatRates()
+		d.fillNative(rates)
+		if !hasVAT(rates) {
+			continue
+		}
+
+		counterpartyCZ := isCzechVATNo(d.vatNo)
+		if d.expense && d.vatNo != "" && !counterpartyCZ {
+			rep.Skipped = append(rep.Skipped, d.skipped("foreign supplier (reverse charge) is not supported"))
+			continue
+		}
+
+		var khRates KHRates
+		unknown := false
+		for _, s := range rates {
+			if s.VAT.IsZero() && s.Rate.IsZero() {
+				continue
+			}
+			slot := rateSlot(s.Rate)
+			if slot == 0 {
+				unknown = true
+				break
+			}
+			khRates.add(slot, s)
+		}
+		if unknown {
+			rep.Skipped = append(rep.Skipped, d.skipped("VAT rate not used in Czech VAT"))
+			continue
+		}
+
+		for _, s := range rates {
+			slot := rateSlot(s.Rate)
+			switch {
+			case slot == 0:
+				continue
+			case slot == 1 && d.expense:
+				rep.InputBasic.add(s)
+			case slot == 1:
+				rep.OutputBasic.add(s)
+			case d.expense:
+				rep.InputReduced.add(s)
+			default:
+				rep.OutputReduced.add(s)
+			}
+		}
+
+		itemised := counterpartyCZ && d.gross.Abs().Cmp(ControlStatementLimit) > 0
+		record := KHRecord{
+			DocumentID: d.id,
+			Number:     d.number,
+			Subject:    d.subject,
+			VATNo:      strings.ToUpper(strings.ReplaceAll(d.vatNo, " ", "")),
+			Reference:  d.reference,
+			Date:       d.date,
+			KHRates:    khRates,
+		}
+		cs := &rep.ControlStatement
+		switch {
+		case d.expense && itemised:
+			cs.B2 = append(cs.B2, record)
+		case d.expense:
+			cs.B3 = sumRates(cs.B3, khRates)
+		case itemised:
+			cs.A4 = append(cs.A4, record)
+		default:
+			cs.A5 = sumRates(cs.A5, khRates)
+		}
+	}
+
+	rep.OutputVAT = rep.OutputBasic.VAT.Add(rep.OutputReduced.VAT)
+	rep.InputVAT = rep.InputBasic.VAT.Add(rep.InputReduced.VAT)
+	if diff := rep.OutputVAT.Sub(rep.InputVAT); diff.Sign() >= 0 {
+		rep.TaxDue = diff
+	} else {
+		rep.ExcessDeduction = diff.Neg()
+	}
+
+	byDate := func(records []KHRecord) {
+		sort.Slice(records, func(i, j int) bool {
+			if records[i].Date != records[j].Date {
+				return records[i].Date < records[j].Date
+			}
+			return records[i].Reference < records[j].Reference
+		})
+	}
+	byDate(rep.ControlStatement.A4)
+	byDate(rep.ControlStatement.B2)
+	return rep
+}
+
+// Table renders the VAT return rows and a control statement overview as Markdown.
+func (rep VATReturn) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Czech VAT %s (%s – %s), CZK\n\n", rep.Period, rep.From, rep.To)
+	b.WriteString("| Row | Description | Base | VAT |\n|---|---|---:|---:|\n")
+	row := func(n, desc string, v VATBase) {
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", n, desc, v.Base.StringFixed(2), v.VAT.StringFixed(2))
+	}
+	row("1", "Output, basic rate", rep.OutputBasic)
+	row("2", "Output, reduced rate", rep.OutputReduced)
+	row("40", "Input, basic rate", rep.InputBasic)
+	row("41", "Input, reduced rate", rep.InputReduced)
+	fmt.Fprintf(&b, "| 62 | Output VAT total | | %s |\n", rep.OutputVAT.StringFixed(2))
+	fmt.Fprintf(&b, "| 63 | Deduction total | | %s |\n", rep.InputVAT.StringFixed(2))
+	fmt.Fprintf(&b, "| 64 | Tax due | | %s |\n", rep.TaxDue.StringFixed(2))
+	fmt.Fprintf(&b, "| 65 | Excess deduction | | %s |\n", rep.ExcessDeduction.StringFixed(2))
+
+	cs := rep.ControlStatement
+	b.WriteString("\nControl statement:\n")
+	fmt.Fprintf(&b, "- A.4: %d documents over %s CZK\n", len(cs.A4), ControlStatementLimit)
+	fmt.Fprintf(&b, "- A.5: base %s / %s, VAT %s / %s\n", cs.A5.Base1.StringFixed(2), cs.A5.Base2.StringFixed(2), cs.A5.VAT1.StringFixed(2), cs.A5.VAT2.StringFixed(2))
+	fmt.Fprintf(&b, "- B.2: %d documents over %s CZK\n", len(cs.B2), ControlStatementLimit)
+	fmt.Fprintf(&b, "- B.3: base %s / %s, VAT %s / %s\n", cs.B3.Base1.StringFixed(2), cs.B3.Base2.StringFixed(2), cs.B3.VAT1.StringFixed(2), cs.B3.VAT2.StringFixed(2))
+
+	if len(rep.Skipped) > 0 {
+		b.WriteString("\nSkipped:\n")
+		for _, s := range rep.Skipped {
+			fmt.Fprintf(&b, "- %s %s (%s): %s\n", s.Type, s.Number, s.Total, s.Reason)
+		}
+	}
+	return b.String()
+}
+
+// rateSlot maps a Czech VAT rate to its control statement column, or 0 for unknown rates.
+func rateSlot(rate fakturoid.Amount) int {
+	switch rate.String() {
+	case "21":
+		return 1
+	case "15", "12":
+		return 2
+	case "10":
+		return 3
+	}
+	return 0
+}
+
+func hasVAT(rates []fakturoid.VATRateSummary) bool {
+	for _, s := range rates {
+		if !s.VAT.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func sumRates(a, b KHRates) KHRates {
+	return KHRates{
+		Base1: a.Base1.Add(b.Base1), VAT1: a.VAT1.Add(b.VAT1),
+		Base2: a.Base2.Add(b.Base2), VAT2: a.VAT2.Add(b.VAT2),
+		Base3: a.Base3.Add(b.Base3), VAT3: a.VAT3.Add(b.VAT3),
+	}
+}
+
+// isCzechVATNo reports whether vatNo looks like a Czech DIČ (CZ followed by 8–10 digits).
+func isCzechVATNo(vatNo string) bool {
+	v := strings.ToUpper(strings.ReplaceAll(vatNo, " ", ""))
+	if !strings.HasPrefix(v, "CZ") || len(v) < 10 || len(v) > 12 {
+		return false
+	}
+	for _, c := range v[2:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func firstDate(dates ...string) string {
+	for _, d := range dates {
+		if d != "" {
+			return d
+		}
+	}
+	return ""
+}
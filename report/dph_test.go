@@ -0,0 +1,171 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// testInvoice is a CZK invoice of one line priced with VAT.
+func testInvoice(id int, date, vatNo, gross, rate string) fakturoid.Invoice {
+	total := fakturoid.MustParseAmount(gross)
+	return fakturoid.Invoice{
+		ID:                    id,
+		Number:                "2026-" + date[5:7] + "-" + string(rune('0'+id)),
+		Currency:              "CZK",
+		IssuedOn:              date,
+		TaxableFulfillmentDue: date,
+		ClientVATNo:           vatNo,
+		Total:                 total,
+		VATPriceMode:          fakturoid.VATPriceModeWithVAT,
+		Lines: []fakturoid.InvoiceLine{
+			{Name: "Služby", Quantity: fakturoid.NewAmount(1), UnitPrice: total, VATRate: fakturoid.MustParseAmount(rate)},
+		},
+	}
+}
+
+func testExpense(id int, date, vatNo, gross, rate string) fakturoid.Expense {
+	total := fakturoid.MustParseAmount(gross)
+	return fakturoid.Expense{
+		ID:             id,
+		Number:         "N" + string(rune('0'+id)),
+		OriginalNumber: "FA" + string(rune('0'+id)),
+		Currency:       "CZK",
+		IssuedOn:       date,
+		SupplierVATNo:  vatNo,
+		Total:          total,
+		VATPriceMode:   fakturoid.VATPriceModeWithVAT,
+		Lines: []fakturoid.ExpenseLine{
+			{Name: "Nákup", Quantity: fakturoid.NewAmount(1), UnitPrice: total, VATRate: fakturoid.MustParseAmount(rate)},
+		},
+	}
+}
+
+func TestCzechVATControlStatementLimit(t *testing.T) {
+	invoices := []fakturoid.Invoice{
+		// Exactly 10 000 CZK with VAT stays in A.5, one haléř more goes to A.4.
+		testInvoice(1, "2026-03-02", "CZ12345678", "10000", "21"),
+		testInvoice(2, "2026-03-05", "CZ12345678", "10000.01", "21"),
+		// Customers without a Czech DIČ are never itemised.
+		testInvoice(3, "2026-03-09", "", "50000", "21"),
+		testInvoice(4, "2026-03-10", "CZ87654321", "11200", "12"),
+		// Outside the period.
+		testInvoice(5, "2026-04-01", "CZ12345678", "50000", "21"),
+	}
+	cancelled := testInvoice(6, "2026-03-11", "CZ12345678", "50000", "21")
+	cancelled.Status = "cancelled"
+	proforma := testInvoice(7, "2026-03-12", "CZ12345678", "50000", "21")
+	proforma.DocumentType = "proforma"
+	invoices = append(invoices, cancelled, proforma)
+
+	expenses := []fakturoid.Expense{
+		testExpense(1, "2026-03-03", "CZ11111111", "24200", "21"),
+		testExpense(2, "2026-03-04", "CZ11111111", "1210", "21"),
+	}
+
+	rep := CzechVAT(invoices, expenses, VATPeriod{Year: 2026, Month: 3})
+	cs := rep.ControlStatement
+
+	if len(cs.A4) != 2 {
+		t.Fatalf("A.4 has %d records, want 2: %+v", len(cs.A4), cs.A4)
+	}
+	if cs.A4[0].DocumentID != 2 || cs.A4[1].DocumentID != 4 {
+		t.Errorf("A.4 documents = %d, %d; want 2, 4", cs.A4[0].DocumentID, cs.A4[1].DocumentID)
+	}
+	if got := cs.A4[0].Base1.String(); got != "8264.47" {
+		t.Errorf("A.4 base of 10 000.01 CZK = %s, want 8264.47", got)
+	}
+	if got := cs.A4[1].Base2.String(); got != "10000" {
+		t.Errorf("A.4 reduced base = %s, want 10000", got)
+	}
+	// 10 000 + 50 000 CZK with VAT at 21 %, rounded per document.
+	if got := cs.A5.Base1.String(); got != "49586.77" {
+		t.Errorf("A.5 base = %s, want 49586.77", got)
+	}
+	if got := cs.A5.VAT1.String(); got != "10413.23" {
+		t.Errorf("A.5 VAT = %s, want 10413.23", got)
+	}
+
+	if len(cs.B2) != 1 || cs.B2[0].Reference != "FA1" || cs.B2[0].VATNo != "CZ11111111" {
+		t.Errorf("B.2 = %+v, want the 24 200 CZK expense FA1", cs.B2)
+	}
+	if got := cs.B3.Base1.String(); got != "1000" {
+		t.Errorf("B.3 base = %s, want 1000", got)
+	}
+
+	if got := rep.OutputVAT.String(); got != "13348.77" {
+		t.Errorf("output VAT = %s, want 13348.77", got)
+	}
+	if got := rep.InputVAT.String(); got != "4410" {
+		t.Errorf("input VAT = %s, want 4410", got)
+	}
+	if got := rep.TaxDue.String(); got != "8938.77" {
+		t.Errorf("tax due = %s, want 8938.77", got)
+	}
+}
+
+func TestCzechVATSkipped(t *testing.T) {
+	foreign := testInvoice(1, "2026-03-02", "CZ12345678", "100", "21")
+	foreign.Currency = "EUR"
+	reverseCharge := testExpense(2, "2026-03-03", "DE123456789", "5000", "21")
+	unknownRate := testInvoice(3, "2026-03-04", "", "1190", "19")
+
+	rep := CzechVAT([]fakturoid.Invoice{foreign, unknownRate}, []fakturoid.Expense{reverseCharge}, VATPeriod{Year: 2026, Quarter: 1})
+	if len(rep.Skipped) != 3 {
+		t.Fatalf("skipped %d documents, want 3: %+v", len(rep.Skipped), rep.Skipped)
+	}
+	if !rep.OutputVAT.IsZero() || !rep.InputVAT.IsZero() {
+		t.Errorf("skipped documents counted: output %s, input %s", rep.OutputVAT, rep.InputVAT)
+	}
+}
+
+func TestVATPeriod(t *testing.T) {
+	tests := []struct {
+		period   VATPeriod
+		wantErr  bool
+		from, to string
+		name     string
+	}{
+		{VATPeriod{Year: 2026, Month: 2}, false, "2026-02-01", "2026-02-28", "2026-02"},
+		{VATPeriod{Year: 2026, Quarter: 4}, false, "2026-10-01", "2026-12-31", "2026-Q4"},
+		{VATPeriod{Year: 2026}, true, "", "", ""},
+		{VATPeriod{Year: 2026, Month: 1, Quarter: 1}, true, "", "", ""},
+		{VATPeriod{Year: 2026, Month: 13}, true, "", "", ""},
+	}
+	for _, tt := range tests {
+		err := tt.period.Validate()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%+v: Validate() = %v, wantErr %v", tt.period, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		from, to := tt.period.Bounds()
+		if from.Format(dateLayout) != tt.from || to.Format(dateLayout) != tt.to || tt.period.String() != tt.name {
+			t.Errorf("%+v: %s – %s (%s), want %s – %s (%s)", tt.period, from.Format(dateLayout), to.Format(dateLayout), tt.period, tt.from, tt.to, tt.name)
+		}
+	}
+}
+
+func TestVATPeriodFilter(t *testing.T) {
+	early := testInvoice(1, "2026-03-31", "", "100", "21")
+	early.IssuedOn = "2026-04-02"
+	invoices := []fakturoid.Invoice{
+		early,
+		testInvoice(2, "2026-04-01", "", "100", "21"),
+		testInvoice(3, "2026-06-30", "", "100", "21"),
+		testInvoice(4, "2026-07-01", "", "100", "21"),
+	}
+	noSupplyDate := testExpense(1, "2026-05-05", "", "100", "21")
+	expenses := []fakturoid.Expense{noSupplyDate, testExpense(2, "2026-03-31", "", "100", "21")}
+
+	period := VATPeriod{Year: 2026, Quarter: 2}
+	got := period.Invoices(invoices)
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Errorf("Q2 invoices = %+v, want 2 and 3", got)
+	}
+	if got := period.Expenses(expenses); len(got) != 1 || got[0].ID != 1 {
+		t.Errorf("Q2 expenses = %+v, want 1", got)
+	}
+}
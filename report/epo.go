@@ -0,0 +1,219 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Taxpayer identifies the filer in the EPO control statement (věta P).
+type Taxpayer struct {
+	VATNo string
+	Name  string
+	// Individual switches the filer type from legal person to natural person.
+	Individual bool
+	Street     string
+	City       string
+	Zip        string
+	Email      string
+	// TaxOffice (c_ufo) and Workplace (c_pracufo) are the tax office codes.
+	TaxOffice string
+	Workplace string
+}
+
+type epoDocument struct {
+	XMLName  xml.Name `xml:"Pisemnost"`
+	Software string   `xml:"nazevSW,attr"`
+	KH       epoKH    `xml:"DPHKH1"`
+}
+
+type epoKH struct {
+	Version string      `xml:"verzePis,attr"`
+	D       epoVetaD    `xml:"VetaD"`
+	P       epoVetaP    `xml:"VetaP"`
+	A4      []epoRecord `xml:"VetaA4"`
+	A5      *epoRates   `xml:"VetaA5"`
+	B2      []epoRecord `xml:"VetaB2"`
+	B3      *epoRates   `xml:"VetaB3"`
+	C       epoVetaC    `xml:"VetaC"`
+}
+
+type epoVetaD struct {
+	Document string `xml:"dokument,attr"`
+	Agenda   string `xml:"k_uladis,attr"`
+	Form     string `xml:"khdph_forma,attr"`
+	Year     int    `xml:"rok,attr"`
+	Month    int    `xml:"mesic,attr,omitempty"`
+	Quarter  int    `xml:"ctvrt,attr,omitempty"`
+	FiledOn  string `xml:"d_poddp,attr"`
+}
+
+type epoVetaP struct {
+	TaxOffice string `xml:"c_ufo,attr,omitempty"`
+	Workplace string `xml:"c_pracufo,attr,omitempty"`
+	VATNo     string `xml:"dic,attr"`
+	Type      string `xml:"typ_ds,attr"`
+	Company   string `xml:"zkrobchjm,attr,omitempty"`
+	FirstName string `xml:"jmeno,attr,omitempty"`
+	LastName  string `xml:"prijmeni,attr,omitempty"`
+	Street    string `xml:"ulice,attr,omitempty"`
+	City      string `xml:"naz_obce,attr,omitempty"`
+	Zip       string `xml:"psc,attr,omitempty"`
+	Country   string `xml:"stat,attr"`
+	Email     string `xml:"email,attr,omitempty"`
+}
+
+type epoRates struct {
+	Base1 string `xml:"zakl_dane1,attr,omitempty"`
+	VAT1  string `xml:"dan1,attr,omitempty"`
+	Base2 string `xml:"zakl_dane2,attr,omitempty"`
+	VAT2  string `xml:"dan2,attr,omitempty"`
+	Base3 string `xml:"zakl_dane3,attr,omitempty"`
+	VAT3  string `xml:"dan3,attr,omitempty"`
+}
+
+// epoRecord is a line of section A.4 (with CustomerVATNo) or B.2 (with SupplierVATNo).
+type epoRecord struct {
+	Line          int    `xml:"c_radku,attr"`
+	CustomerVATNo string `xml:"dic_odb,attr,omitempty"`
+	SupplierVATNo string `xml:"dic_dod,attr,omitempty"`
+	Reference     string `xml:"c_evid_dd,attr"`
+	Date          string `xml:"dppd,attr"`
+	epoRates
+	Regime     string `xml:"kod_rezim_pl,attr,omitempty"`
+	Ratio      string `xml:"pomer,attr,omitempty"`
+	Insolvency string `xml:"zdph_44,attr"`
+}
+
+type epoVetaC struct {
+	Sales23    string `xml:"obrat23,attr,omitempty"`
+	Sales5     string `xml:"obrat5,attr,omitempty"`
+	Received23 string `xml:"pln23,attr,omitempty"`
+	Received5  string `xml:"pln5,attr,omitempty"`
+}
+
+// ControlStatementXML renders the control statement in the EPO XML format (DPHKH1) for
+// import into the tax portal. It is meant for review; nothing is submitted.
+func (rep VATReturn) ControlStatementXML(tp Taxpayer, filedOn time.Time) ([]byte, error) {
+	if tp.VATNo == "" {
+		return nil, fmt.Errorf("taxpayer VAT number is required")
+	}
+
+	cs := rep.ControlStatement
+	kh := epoKH{
+		Version: "03.01",
+		D: epoVetaD{
+			Document: "KH1",
+			Agenda:   "DPH",
+			Form:     "B",
+			Year:     rep.period.Year,
+			Month:    rep.period.Month,
+			Quarter:  rep.period.Quarter,
+			FiledOn:  filedOn.Format("02.01.2006"),
+		},
+		P: epoVetaP{
+			TaxOffice: tp.TaxOffice,
+			Workplace: tp.Workplace,
+			VATNo:     epoVATNo(tp.VATNo),
+			Type:      "P",
+			Company:   tp.Name,
+			Street:    tp.Street,
+			City:      tp.City,
+			Zip:       strings.ReplaceAll(tp.Zip, " ", ""),
+			Country:   "ČESKÁ REPUBLIKA",
+			Email:     tp.Email,
+		},
+	}
+	if tp.Individual {
+		kh.P.Type = "F"
+		kh.P.Company = ""
+		if i := strings.LastIndex(tp.Name, " "); i > 0 {
+			kh.P.FirstName, kh.P.LastName = tp.Name[:i], tp.Name[i+1:]
+		} else {
+			kh.P.LastName = tp.Name
+		}
+	}
+
+	for i, r := range cs.A4 {
+		kh.A4 = append(kh.A4, epoRecord{
+			Line:          i + 1,
+			CustomerVATNo: epoVATNo(r.VATNo),
+			Reference:     r.Reference,
+			Date:          epoDate(r.Date),
+			epoRates:      toEPORates(r.KHRates),
+			Regime:        "0",
+			Insolvency:    "N",
+		})
+	}
+	for i, r := range cs.B2 {
+		kh.B2 = append(kh.B2, epoRecord{
+			Line:          i + 1,
+			SupplierVATNo: epoVATNo(r.VATNo),
+			Reference:     r.Reference,
+			Date:          epoDate(r.Date),
+			epoRates:      toEPORates(r.KHRates),
+			Ratio:         "N",
+			Insolvency:    "N",
+		})
+	}
+	if a5 := toEPORates(cs.A5); a5 != (epoRates{}) {
+		kh.A5 = &a5
+	}
+	if b3 := toEPORates(cs.B3); b3 != (epoRates{}) {
+		kh.B3 = &b3
+	}
+
+	// Věta C carries control sums of sections A.4+A.5 and B.2+B.3.
+	sales, received := cs.A5, cs.B3
+	for _, r := range cs.A4 {
+		sales = sumRates(sales, r.KHRates)
+	}
+	for _, r := range cs.B2 {
+		received = sumRates(received, r.KHRates)
+	}
+	kh.C = epoVetaC{
+		Sales23:    epoAmount(sales.Base1),
+		Sales5:     epoAmount(sales.Base2.Add(sales.Base3)),
+		Received23: epoAmount(received.Base1),
+		Received5:  epoAmount(received.Base2.Add(received.Base3)),
+	}
+
+	data, err := xml.MarshalIndent(epoDocument{Software: "fakturoid-mcp", KH: kh}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal control statement: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+func toEPORates(k KHRates) epoRates {
+	return epoRates{
+		Base1: epoAmount(k.Base1), VAT1: epoAmount(k.VAT1),
+		Base2: epoAmount(k.Base2), VAT2: epoAmount(k.VAT2),
+		Base3: epoAmount(k.Base3), VAT3: epoAmount(k.VAT3),
+	}
+}
+
+// epoAmount formats an amount with two decimals, leaving zero empty so the attribute is omitted.
+func epoAmount(a fakturoid.Amount) string {
+	if a.IsZero() {
+		return ""
+	}
+	return a.StringFixed(2)
+}
+
+// epoVATNo strips the country prefix; EPO expects only the digits of a Czech DIČ.
+func epoVATNo(vatNo string) string {
+	v := strings.ToUpper(strings.ReplaceAll(vatNo, " ", ""))
+	return strings.TrimPrefix(v, "CZ")
+}
+
+func epoDate(date string) string {
+	t, err := time.Parse(dateLayout, date)
+	if err != nil {
+		return date
+	}
+	return t.Format("02.01.2006")
+}
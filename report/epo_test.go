@@ -0,0 +1,89 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+// golden compares got with testdata/name, or rewrites the file with -update.
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs from the golden file:\n%s", name, got)
+	}
+}
+
+func testVATReturn() VATReturn {
+	invoices := []fakturoid.Invoice{
+		testInvoice(1, "2026-03-02", "CZ12345678", "24200", "21"),
+		testInvoice(2, "2026-03-05", "", "1210", "21"),
+		testInvoice(3, "2026-03-06", "CZ87654321", "1120", "12"),
+	}
+	expenses := []fakturoid.Expense{
+		testExpense(1, "2026-03-03", "CZ 111 111 11", "36300", "21"),
+		testExpense(2, "2026-03-04", "CZ11111111", "560", "12"),
+	}
+	return CzechVAT(invoices, expenses, VATPeriod{Year: 2026, Month: 3})
+}
+
+func TestControlStatementXML(t *testing.T) {
+	data, err := testVATReturn().ControlStatementXML(Taxpayer{
+		VATNo:     "CZ01234567",
+		Name:      "Example s.r.o.",
+		Street:    "Dlouhá 1",
+		City:      "Praha",
+		Zip:       "110 00",
+		Email:     "ucetni@example.com",
+		TaxOffice: "451",
+		Workplace: "2001",
+	}, time.Date(2026, 4, 20, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "kh_legal.xml", data)
+}
+
+func TestControlStatementXMLIndividual(t *testing.T) {
+	data, err := testVATReturn().ControlStatementXML(Taxpayer{
+		VATNo:      "CZ8001011234",
+		Name:       "Jan Novák",
+		Individual: true,
+	}, time.Date(2026, 4, 20, 0, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml := string(data)
+	for _, want := range []string{`typ_ds="F"`, `jmeno="Jan"`, `prijmeni="Novák"`, `dic="8001011234"`} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("XML lacks %s:\n%s", want, xml)
+		}
+	}
+	if strings.Contains(xml, "zkrobchjm") {
+		t.Errorf("natural person has a company name:\n%s", xml)
+	}
+}
+
+func TestControlStatementXMLRequiresVATNo(t *testing.T) {
+	if _, err := testVATReturn().ControlStatementXML(Taxpayer{Name: "Example s.r.o."}, time.Now()); err == nil {
+		t.Error("rendered a control statement without the taxpayer's VAT number")
+	}
+}
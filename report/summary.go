@@ -0,0 +1,304 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// Bases for assigning documents to periods.
+const (
+	BasisIssued = "issued"
+	BasisPaid   = "paid"
+)
+
+// Period granularities.
+const (
+	PeriodMonth   = "month"
+	PeriodQuarter = "quarter"
+)
+
+const noTag = "(no tag)"
+
+type SummaryOptions struct {
+	From            time.Time
+	To              time.Time
+	Basis           string
+	Period          string
+	AccountCurrency string
+}
+
+func (opts SummaryOptions) invoiceDate(inv fakturoid.Invoice) string {
+	if opts.Basis == BasisPaid {
+		return inv.PaidOn
+	}
+	return inv.IssuedOn
+}
+
+func (opts SummaryOptions) expenseDate(exp fakturoid.Expense) string {
+	if opts.Basis == BasisPaid {
+		return exp.PaidOn
+	}
+	return exp.IssuedOn
+}
+
+// Invoices returns the invoices whose basis date falls within [From, To], so that only
+// they are prepared (e.g. given exchange rates) before Summary.
+func (opts SummaryOptions) Invoices(invoices []fakturoid.Invoice) []fakturoid.Invoice {
+	var in []fakturoid.Invoice
+	for _, inv := range invoices {
+		if _, ok := dayIn(opts.invoiceDate(inv), opts.From, opts.To); ok {
+			in = append(in, inv)
+		}
+	}
+	return in
+}
+
+// Expenses is Invoices for expenses.
+func (opts SummaryOptions) Expenses(expenses []fakturoid.Expense) []fakturoid.Expense {
+	var in []fakturoid.Expense
+	for _, exp := range expenses {
+		if _, ok := dayIn(opts.expenseDate(exp), opts.From, opts.To); ok {
+			in = append(in, exp)
+		}
+	}
+	return in
+}
+
+// SummaryRow holds revenue (invoices) and expenses for one group, in account currency.
+// Net amounts exclude VAT, gross amounts include it.
+type SummaryRow struct {
+	Key           string           `json:"key"`
+	RevenueNet    fakturoid.Amount `json:"revenue_net"`
+	RevenueGross  fakturoid.Amount `json:"revenue_gross"`
+	ExpensesNet   fakturoid.Amount `json:"expenses_net"`
+	ExpensesGross fakturoid.Amount `json:"expenses_gross"`
+	ProfitNet     fakturoid.Amount `json:"profit_net"`
+	Invoices      int              `json:"invoices"`
+	Expenses      int              `json:"expenses"`
+}
+
+// CurrencyRow holds gross totals in the documents' own currency.
+type CurrencyRow struct {
+	Currency       string           `json:"currency"`
+	Revenue        fakturoid.Amount `json:"revenue"`
+	Expenses       fakturoid.Amount `json:"expenses"`
+	RevenueNative  fakturoid.Amount `json:"revenue_native"`
+	ExpensesNative fakturoid.Amount `json:"expenses_native"`
+}
+
+// VATRow holds output VAT (invoices) and input VAT (expenses) for one rate, in account currency.
+type VATRow struct {
+	Rate       fakturoid.Amount `json:"rate"`
+	OutputBase fakturoid.Amount `json:"output_base"`
+	OutputVAT  fakturoid.Amount `json:"output_vat"`
+	InputBase  fakturoid.Amount `json:"input_base"`
+	InputVAT   fakturoid.Amount `json:"input_vat"`
+}
+
+type SkippedDocument struct {
+	Type   string          `json:"type"`
+	ID     int             `json:"id"`
+	Number string          `json:"number"`
+	Total  fakturoid.Money `json:"total"`
+	Reason string          `json:"reason"`
+}
+
+type SummaryReport struct {
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Basis      string            `json:"basis"`
+	Period     string            `json:"period"`
+	Currency   string            `json:"currency"`
+	Totals     SummaryRow        `json:"totals"`
+	ByPeriod   []SummaryRow      `json:"by_period"`
+	BySubject  []SummaryRow      `json:"by_subject"`
+	ByTag      []SummaryRow      `json:"by_tag"`
+	ByCurrency []CurrencyRow     `json:"by_currency"`
+	ByVATRate  []VATRow          `json:"by_vat_rate"`
+	Skipped    []SkippedDocument `json:"skipped,omitempty"`
+}
+
+// Summary aggregates invoices and expenses whose basis date falls within [From, To].
+func Summary(invoices []fakturoid.Invoice, expenses []fakturoid.Expense, opts SummaryOptions) SummaryReport {
+	if opts.Basis == "" {
+		opts.Basis = BasisIssued
+	}
+	if opts.Period == "" {
+		opts.Period = PeriodMonth
+	}
+	rep := SummaryReport{
+		From:     opts.From.Format(dateLayout),
+		To:       opts.To.Format(dateLayout),
+		Basis:    opts.Basis,
+		Period:   opts.Period,
+		Currency: strings.ToUpper(opts.AccountCurrency),
+		Totals:   SummaryRow{Key: "total"},
+	}
+
+	var docs []document
+	for _, inv := range invoices {
+		if inv.Status == "cancelled" || IsProforma(inv) {
+			continue
+		}
+		docs = append(docs, invoiceDocument(inv, opts.invoiceDate(inv), opts.AccountCurrency))
+	}
+	for _, exp := range expenses {
+		docs = append(docs, expenseDocument(exp, opts.expenseDate(exp), opts.AccountCurrency))
+	}
+
+	periods := map[string]*SummaryRow{}
+	subjects := map[string]*SummaryRow{}
+	tags := map[string]*SummaryRow{}
+	currencies := map[string]*CurrencyRow{}
+	rates := map[string]*VATRow{}
+
+	for _, d := range docs {
+		day, ok := dayIn(d.date, opts.From, opts.To)
+		if !ok {
+			continue
+		}
+		if d.factor.IsZero() {
+			rep.Skipped = append(rep.Skipped, d.skipped(fmt.Sprintf("no exchange rate to %s", rep.Currency)))
+			continue
+		}
+		vatRates := d.vatRates()
+		d.fillNative(vatRates)
+
+		d.addTo(&rep.Totals)
+		d.addTo(row(periods, periodKey(day, opts.Period)))
+		d.addTo(row(subjects, d.subject))
+		if len(d.tags) == 0 {
+			d.addTo(row(tags, noTag))
+		}
+		for _, t := range d.tags {
+			d.addTo(row(tags, t))
+		}
+
+		c, ok := currencies[d.currency]
+		if !ok {
+			c = &CurrencyRow{Currency: d.currency}
+			currencies[d.currency] = c
+		}
+		if d.expense {
+			c.Expenses = c.Expenses.Add(d.total)
+			c.ExpensesNative = c.ExpensesNative.Add(d.gross)
+		} else {
+			c.Revenue = c.Revenue.Add(d.total)
+			c.RevenueNative = c.RevenueNative.Add(d.gross)
+		}
+
+		for _, s := range vatRates {
+			key := s.Rate.String()
+			v, ok := rates[key]
+			if !ok {
+				v = &VATRow{Rate: s.Rate}
+				rates[key] = v
+			}
+			if d.expense {
+				v.InputBase = v.InputBase.Add(s.Base)
+				v.InputVAT = v.InputVAT.Add(s.VAT)
+			} else {
+				v.OutputBase = v.OutputBase.Add(s.Base)
+				v.OutputVAT = v.OutputVAT.Add(s.VAT)
+			}
+		}
+	}
+
+	rep.ByPeriod = sortedRows(periods, func(a, b SummaryRow) bool { return a.Key < b.Key })
+	rep.BySubject = sortedRows(subjects, func(a, b SummaryRow) bool {
+		av, bv := a.RevenueNet.Add(a.ExpensesNet), b.RevenueNet.Add(b.ExpensesNet)
+		if c := av.Cmp(bv); c != 0 {
+			return c > 0
+		}
+		return a.Key < b.Key
+	})
+	rep.ByTag = sortedRows(tags, func(a, b SummaryRow) bool { return a.Key < b.Key })
+
+	rep.ByCurrency = []CurrencyRow{}
+	for _, c := range currencies {
+		rep.ByCurrency = append(rep.ByCurrency, *c)
+	}
+	sort.Slice(rep.ByCurrency, func(i, j int) bool { return rep.ByCurrency[i].Currency < rep.ByCurrency[j].Currency })
+
+	rep.ByVATRate = []VATRow{}
+	for _, v := range rates {
+		rep.ByVATRate = append(rep.ByVATRate, *v)
+	}
+	sort.Slice(rep.ByVATRate, func(i, j int) bool { return rep.ByVATRate[i].Rate.Cmp(rep.ByVATRate[j].Rate) < 0 })
+	return rep
+}
+
+// Table renders the per-period and per-VAT-rate breakdown as Markdown tables.
+func (rep SummaryReport) Table() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Revenue and expenses %s – %s (%s basis, %s)\n\n", rep.From, rep.To, rep.Basis, rep.Currency)
+	b.WriteString("| Period | Revenue (net) | Expenses (net) | Profit (net) | Invoices | Expenses |\n")
+	b.WriteString("|---|---:|---:|---:|---:|---:|\n")
+	for _, r := range append(append([]SummaryRow{}, rep.ByPeriod...), rep.Totals) {
+		key := r.Key
+		if key == "total" {
+			key = "**Total**"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %d | %d |\n", key,
+			r.RevenueNet.StringFixed(2), r.ExpensesNet.StringFixed(2), r.ProfitNet.StringFixed(2), r.Invoices, r.Expenses)
+	}
+
+	if len(rep.ByVATRate) > 0 {
+		b.WriteString("\n| VAT rate | Output base | Output VAT | Input base | Input VAT |\n")
+		b.WriteString("|---:|---:|---:|---:|---:|\n")
+		for _, v := range rep.ByVATRate {
+			fmt.Fprintf(&b, "| %s %% | %s | %s | %s | %s |\n", v.Rate,
+				v.OutputBase.StringFixed(2), v.OutputVAT.StringFixed(2), v.InputBase.StringFixed(2), v.InputVAT.StringFixed(2))
+		}
+	}
+
+	if len(rep.Skipped) > 0 {
+		b.WriteString("\nSkipped:\n")
+		for _, s := range rep.Skipped {
+			fmt.Fprintf(&b, "- %s %s (%s): %s\n", s.Type, s.Number, s.Total, s.Reason)
+		}
+	}
+	return b.String()
+}
+
+func (d *document) addTo(r *SummaryRow) {
+	if d.expense {
+		r.ExpensesNet = r.ExpensesNet.Add(d.net)
+		r.ExpensesGross = r.ExpensesGross.Add(d.gross)
+		r.Expenses++
+	} else {
+		r.RevenueNet = r.RevenueNet.Add(d.net)
+		r.RevenueGross = r.RevenueGross.Add(d.gross)
+		r.Invoices++
+	}
+	r.ProfitNet = r.RevenueNet.Sub(r.ExpensesNet)
+}
+
+func row(rows map[string]*SummaryRow, key string) *SummaryRow {
+	r, ok := rows[key]
+	if !ok {
+		r = &SummaryRow{Key: key}
+		rows[key] = r
+	}
+	return r
+}
+
+func sortedRows(rows map[string]*SummaryRow, less func(a, b SummaryRow) bool) []SummaryRow {
+	result := make([]SummaryRow, 0, len(rows))
+	for _, r := range rows {
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool { return less(result[i], result[j]) })
+	return result
+}
+
+func periodKey(day time.Time, period string) string {
+	if period == PeriodQuarter {
+		return fmt.Sprintf("%d-Q%d", day.Year(), (int(day.Month())-1)/3+1)
+	}
+	return day.Format("2006-01")
+}
@@ -6,12 +6,13 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
 )
 
 func registerAccountTools(s *server.MCPServer, r *registry) {
 	s.AddTool(
 		mcp.NewTool("fakturoid_account_info",
-			mcp.WithDescription("Get account information (company name, address, plan, currency, etc.)"),
+			mcp.WithDescription("Get account information (company name, address, plan, currency) and document defaults (due days, VAT mode, default bank account)"),
 		),
 		accountInfoHandler(r),
 	)
@@ -22,6 +23,32 @@ func registerAccountTools(s *server.MCPServer, r *registry) {
 		),
 		eventsHandler(r),
 	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_bank_accounts",
+			mcp.WithDescription("List bank accounts (use the id as bank_account_id when creating invoices)"),
+		),
+		bankAccountsHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_number_formats",
+			mcp.WithDescription("List invoice number formats (use the id as number_format_id when creating invoices)"),
+		),
+		numberFormatsHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_users",
+			mcp.WithDescription("List users with access to the account"),
+		),
+		usersHandler(r),
+	)
+}
+
+type accountInfo struct {
+	*fakturoid.Account
+	DefaultBankAccount *fakturoid.BankAccount `json:"default_bank_account,omitempty"`
 }
 
 func accountInfoHandler(r *registry) server.ToolHandlerFunc {
@@ -30,7 +57,18 @@ func accountInfoHandler(r *registry) server.ToolHandlerFunc {
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
 		}
-		return mcp.NewToolResultText(toJSON(account)), nil
+
+		info := accountInfo{Account: account}
+		// Bank accounts are a nice-to-have here; the account itself is still useful without them.
+		if banks, err := r.client.GetBankAccounts(); err == nil {
+			for i := range banks {
+				if banks[i].Default {
+					info.DefaultBankAccount = &banks[i]
+					break
+				}
+			}
+		}
+		return mcp.NewToolResultText(toJSON(info)), nil
 	}
 }
 
@@ -43,3 +81,33 @@ func eventsHandler(r *registry) server.ToolHandlerFunc {
 		return mcp.NewToolResultText(toJSON(events)), nil
 	}
 }
+
+func bankAccountsHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		banks, err := r.client.GetBankAccounts()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get bank accounts: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(banks)), nil
+	}
+}
+
+func numberFormatsHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		formats, err := r.client.GetNumberFormats()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get number formats: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(formats)), nil
+	}
+}
+
+func usersHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		users, err := r.client.GetUsers()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get users: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(users)), nil
+	}
+}
@@ -0,0 +1,245 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/bankstmt"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerBankTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_bank_statement_match",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Parse a bank statement (ABO/GPC, CAMT.053 XML or CSV export of a Czech bank) and match incoming payments to open invoices by variable symbol, amount and counterparty. " +
+					"Proposes payments for the matched and partial transactions, skipping those already recorded on the invoice, and returns a confirmation code; " +
+					"call again with record=true and confirm=<code> to record exactly the proposed payments. Pass path, content_base64 or resource."),
+				mcp.WithString("format", mcp.Description("gpc, camt or csv (default: detected)")),
+				mcp.WithBoolean("record", mcp.Description("Record the proposed payments; requires confirm (default false: proposal only)")),
+				mcp.WithString("confirm", mcp.Description("Confirmation code from the proposal")),
+				mcp.WithArray("transaction_ids", mcp.WithStringItems(), mcp.Description("With record=true, only record these transaction IDs")),
+				mcp.WithNumber("bank_account_id", mcp.Description("Fakturoid bank account ID to record payments to")),
+			}, fileParamOptions...)...,
+		),
+		bankStatementMatchHandler(r),
+	)
+}
+
+// proposedPayment is a matched or partial transaction to record on its invoice. Skipped
+// explains why it is not recorded, e.g. because the invoice already has the payment.
+type proposedPayment struct {
+	TransactionID string           `json:"transaction_id"`
+	InvoiceID     int              `json:"invoice_id"`
+	Number        string           `json:"number"`
+	Date          string           `json:"date"`
+	Amount        fakturoid.Amount `json:"amount"`
+	Skipped       string           `json:"skipped,omitempty"`
+	PaymentID     int              `json:"payment_id,omitempty"`
+	Error         string           `json:"error,omitempty"`
+
+	match bankstmt.Match
+}
+
+type statementMatch struct {
+	bankstmt.MatchResult
+	Transactions int               `json:"transactions"`
+	Payments     []proposedPayment `json:"payments,omitempty"`
+	Recorded     bool              `json:"recorded"`
+	Confirmation string            `json:"confirmation,omitempty"`
+}
+
+func bankStatementMatchHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		file, err := fileParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid file: %v", err)), nil
+		}
+		transactions, err := bankstmt.Parse(file.Data, req.GetString("format", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse statement: %v", err)), nil
+		}
+
+		invoices, err := unpaidInvoices(r, 0, true)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+		}
+
+		result := statementMatch{
+			MatchResult:  bankstmt.MatchPayments(transactions, invoices),
+			Transactions: len(transactions),
+		}
+
+		only := map[string]bool{}
+		for _, id := range req.GetStringSlice("transaction_ids", nil) {
+			only[id] = true
+		}
+		var selected []bankstmt.Match
+		for _, m := range append(append([]bankstmt.Match{}, result.Matched...), result.Partial...) {
+			if len(only) == 0 || only[m.Transaction.ID] {
+				selected = append(selected, m)
+			}
+		}
+		result.Payments, err = proposePayments(r, selected)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice payments: %v", err)), nil
+		}
+
+		bankAccountID := intParam(req, "bank_account_id", 0)
+		code := paymentsCode(result.Payments, bankAccountID)
+		pending := 0
+		for _, p := range result.Payments {
+			if p.Skipped == "" {
+				pending++
+			}
+		}
+		confirm := req.GetString("confirm", "")
+		if !req.GetBool("record", false) || confirm == "" || pending == 0 {
+			if pending > 0 {
+				result.Confirmation = code
+			}
+			return tableAndJSONResult(statementMatchTable(result), result), nil
+		}
+		if confirm != code {
+			return mcp.NewToolResultError("The proposed payments changed since the proposal (or the confirmation code is wrong). Nothing was recorded; match the statement again."), nil
+		}
+
+		for i := range result.Payments {
+			if result.Payments[i].Skipped == "" {
+				recordPayment(r, &result.Payments[i], bankAccountID)
+			}
+		}
+		result.Recorded = true
+		return tableAndJSONResult(statementMatchTable(result), result), nil
+	}
+}
+
+// proposePayments lists the payments to record for matches. A transaction whose date,
+// amount and variable symbol equal a payment already on the invoice is skipped, so
+// recording a statement twice or after Fakturoid's own bank pairing adds nothing.
+func proposePayments(r *registry, matches []bankstmt.Match) ([]proposedPayment, error) {
+	existing := map[int][]fakturoid.InvoicePayment{}
+	claimed := map[int]bool{}
+	var result []proposedPayment
+	for _, m := range matches {
+		t := m.Transaction
+		p := proposedPayment{
+			TransactionID: t.ID,
+			InvoiceID:     m.Invoice.ID,
+			Number:        m.Invoice.Number,
+			Date:          t.Date,
+			Amount:        t.Amount,
+			match:         m,
+		}
+		payments, ok := existing[m.Invoice.ID]
+		if !ok {
+			var err error
+			if payments, err = r.client.GetInvoicePayments(m.Invoice.ID); err != nil {
+				return nil, fmt.Errorf("invoice %s: %w", m.Invoice.Number, err)
+			}
+			existing[m.Invoice.ID] = payments
+		}
+		for _, ep := range payments {
+			if !claimed[ep.ID] && ep.PaidOn == t.Date && ep.Amount.Cmp(t.Amount) == 0 &&
+				bankstmt.NormalizeSymbol(ep.VariableSymbol) == bankstmt.NormalizeSymbol(t.VariableSymbol) {
+				claimed[ep.ID] = true
+				p.Skipped = fmt.Sprintf("already recorded as payment %d", ep.ID)
+				break
+			}
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// paymentsCode identifies the payments to record, so a confirmed call records exactly
+// what was proposed.
+func paymentsCode(payments []proposedPayment, bankAccountID int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "record|%d", bankAccountID)
+	for _, p := range payments {
+		if p.Skipped == "" {
+			fmt.Fprintf(h, "|%s:%s:%s:%d", p.TransactionID, p.Date, p.Amount, p.InvoiceID)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:10]
+}
+
+func recordPayment(r *registry, p *proposedPayment, bankAccountID int) {
+	m := p.match
+	t := m.Transaction
+	amount := t.Amount
+	payment, err := r.client.CreateInvoicePayment(m.Invoice.ID, fakturoid.CreateInvoicePaymentRequest{
+		PaidOn:         t.Date,
+		Currency:       m.Invoice.Currency,
+		Amount:         &amount,
+		VariableSymbol: t.VariableSymbol,
+		BankAccountID:  bankAccountID,
+	})
+	if err != nil {
+		p.Error = err.Error()
+		return
+	}
+	p.PaymentID = payment.ID
+}
+
+func statementMatchTable(res statementMatch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d transactions: %d matched, %d partial, %d ambiguous, %d unmatched, %d outgoing\n",
+		res.Transactions, len(res.Matched), len(res.Partial), len(res.Ambiguous), len(res.Unmatched), res.Outgoing)
+
+	section := func(title string, matches []bankstmt.Match) {
+		if len(matches) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s:\n\n| Date | Amount | VS | Counterparty | Invoice | Reason |\n|---|---:|---|---|---|---|\n", title)
+		for _, m := range matches {
+			t := m.Transaction
+			invoice := ""
+			if m.Invoice != nil {
+				invoice = m.Invoice.Number
+			}
+			for i, c := range m.Candidates {
+				if i > 0 {
+					invoice += ", "
+				}
+				invoice += c.Number + "?"
+			}
+			fmt.Fprintf(&b, "| %s | %s %s | %s | %s | %s | %s |\n", t.Date, t.Amount.StringFixed(2), t.Currency,
+				t.VariableSymbol, t.CounterpartyName, invoice, m.Reason)
+		}
+	}
+	section("Matched", res.Matched)
+	section("Partial payments", res.Partial)
+	section("Ambiguous", res.Ambiguous)
+	section("Unmatched", res.Unmatched)
+
+	if len(res.Payments) > 0 {
+		if res.Recorded {
+			b.WriteString("\nRecorded:\n")
+		} else {
+			b.WriteString("\nPayments to record:\n")
+		}
+		for _, p := range res.Payments {
+			switch {
+			case p.Skipped != "":
+				fmt.Fprintf(&b, "- %s: %s %s skipped, %s\n", p.Number, p.Date, p.Amount.StringFixed(2), p.Skipped)
+			case p.Error != "":
+				fmt.Fprintf(&b, "- %s: %s %s failed: %s\n", p.Number, p.Date, p.Amount.StringFixed(2), p.Error)
+			case p.PaymentID != 0:
+				fmt.Fprintf(&b, "- %s: %s %s recorded as payment %d\n", p.Number, p.Date, p.Amount.StringFixed(2), p.PaymentID)
+			default:
+				fmt.Fprintf(&b, "- %s: %s %s\n", p.Number, p.Date, p.Amount.StringFixed(2))
+			}
+		}
+	}
+	if res.Confirmation != "" {
+		fmt.Fprintf(&b, "\nNothing was recorded yet. To record these payments, call again with the same statement, record=true and confirm=%q.\n", res.Confirmation)
+	}
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"encoding/base64"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBankStatementRecord(t *testing.T) {
+	statement := "Datum;Objem;Měna;VS;Název protiúčtu;ID pohybu\n" +
+		"10.03.2026;1000,00;CZK;20260001;Alfa s.r.o.;t1\n" +
+		"11.03.2026;500,00;CZK;20260002;Beta a.s.;t2\n"
+	var posted atomic.Int32
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /invoices.json": func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Get("status") != "open" {
+				writeJSON(w, []any{})
+				return
+			}
+			writeJSON(w, []map[string]any{
+				{"id": 1, "number": "2026-0001", "variable_symbol": "20260001", "currency": "CZK", "total": "1000", "remaining_amount": "1000"},
+				{"id": 2, "number": "2026-0002", "variable_symbol": "20260002", "currency": "CZK", "total": "2500", "remaining_amount": "2500"},
+			})
+		},
+		// The first payment was already recorded, e.g. by Fakturoid's bank pairing.
+		"GET /invoices/1/payments.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, []map[string]any{{"id": 9, "paid_on": "2026-03-10", "amount": "1000", "currency": "CZK", "variable_symbol": "0020260001"}})
+		},
+		"GET /invoices/2/payments.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, []any{})
+		},
+		"POST /invoices/2/payments.json": func(w http.ResponseWriter, _ *http.Request) {
+			posted.Add(1)
+			writeJSON(w, map[string]any{"id": 10, "paid_on": "2026-03-11", "amount": "500"})
+		},
+		"POST /invoices/1/payments.json": func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("recorded a payment the invoice already has")
+		},
+	})
+	h := bankStatementMatchHandler(r)
+	args := func(extra map[string]any) map[string]any {
+		a := map[string]any{"content_base64": base64.StdEncoding.EncodeToString([]byte(statement)), "format": "csv"}
+		for k, v := range extra {
+			a[k] = v
+		}
+		return a
+	}
+
+	proposal := callTool(t, h, args(nil)).StructuredContent.(statementMatch)
+	if len(proposal.Payments) != 2 || proposal.Payments[0].Skipped == "" || proposal.Payments[1].Skipped != "" {
+		t.Fatalf("payments = %+v, want the first skipped as already recorded", proposal.Payments)
+	}
+	if proposal.Confirmation == "" || proposal.Recorded {
+		t.Fatalf("proposal: confirmation %q, recorded %v", proposal.Confirmation, proposal.Recorded)
+	}
+
+	if res := callTool(t, h, args(map[string]any{"record": true})); res.IsError || res.StructuredContent.(statementMatch).Recorded {
+		t.Error("record=true without confirm recorded payments")
+	}
+	if res := callTool(t, h, args(map[string]any{"record": true, "confirm": "0000000000"})); !res.IsError {
+		t.Errorf("wrong confirmation accepted: %s", resultText(res))
+	}
+	if posted.Load() != 0 {
+		t.Fatalf("%d payments recorded before confirmation", posted.Load())
+	}
+
+	done := callTool(t, h, args(map[string]any{"record": true, "confirm": proposal.Confirmation})).StructuredContent.(statementMatch)
+	if !done.Recorded || done.Payments[1].PaymentID != 10 || posted.Load() != 1 {
+		t.Errorf("recorded %v, payments %+v, %d posted; want only payment 10", done.Recorded, done.Payments, posted.Load())
+	}
+}
@@ -0,0 +1,761 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/report"
+)
+
+const (
+	bulkDefaultConcurrency = 3
+	bulkMaxConcurrency     = 5
+	bulkMaxItems           = 200
+	bulkRetries            = 3
+	// bulkRetryDelay is the wait before the first retry of a rate-limited request; it
+	// doubles with each further retry.
+	bulkRetryDelay = 2 * time.Second
+)
+
+func registerBulkTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_bulk_create",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Create many invoices in one call. Pass invoices as an array, or a CSV file via path, content_base64 or resource. " +
+					"All invoices are validated first and nothing is created if any is invalid. Returns a result per invoice."),
+				mcp.WithArray("invoices", mcp.Description("Invoices (array of {ref, subject_id, lines, currency, exchange_rate, note, due_on, issued_on, taxable_fulfillment_due, vat_price_mode, bank_account_id, number_format_id}); lines as in fakturoid_invoice_create, ref is an optional label for the result table")),
+				mcp.WithNumber("concurrency", mcp.Description("Invoices created in parallel (default 3, max 5); 1 keeps invoice numbers in input order")),
+				mcp.WithBoolean("stop_on_error", mcp.Description("Stop creating after the first failure and skip the rest (default false)")),
+				mcp.WithBoolean("validate_only", mcp.Description("Only validate and preview totals, create nothing (default false)")),
+			}, fileParamOptions...)...,
+		),
+		invoiceBulkCreateHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_bulk_action",
+			mcp.WithDescription("Send, mark as sent, lock or cancel many invoices at once. Select them by invoice_ids or by filters. "+
+				"The first call only previews the affected invoices and returns a confirmation code; call again with the same selection and confirm=<code> to apply."),
+			mcp.WithString("action", mcp.Required(), mcp.Description("send, mark_sent, lock or cancel")),
+			mcp.WithArray("invoice_ids", mcp.WithNumberItems(), mcp.Description("Invoice IDs (instead of filters)")),
+			mcp.WithString("status", mcp.Description("Filter by status: open, sent, overdue, paid, cancelled")),
+			mcp.WithNumber("subject_id", mcp.Description("Filter by subject (contact) ID")),
+			mcp.WithString("month", mcp.Description("Filter by issue month (YYYY-MM)")),
+			mcp.WithString("issued_from", mcp.Description("Filter by issue date from (YYYY-MM-DD)")),
+			mcp.WithString("issued_to", mcp.Description("Filter by issue date to (YYYY-MM-DD)")),
+			mcp.WithString("template", mcp.Description("send: email template name (default: Fakturoid's email)")),
+			mcp.WithString("language", mcp.Description("send: template language (default: per subject or invoice)")),
+			mcp.WithString("confirm", mcp.Description("Confirmation code from the preview; applies the action")),
+			mcp.WithBoolean("stop_on_error", mcp.Description("Stop after the first failure and skip the rest (default false)")),
+		),
+		invoiceBulkActionHandler(r),
+	)
+}
+
+// bulkInvoice is one invoice of a bulk create.
+type bulkInvoice struct {
+	Ref string `json:"ref,omitempty"`
+	fakturoid.CreateInvoiceRequest
+}
+
+type bulkCreateItem struct {
+	Index     int              `json:"index"`
+	Ref       string           `json:"ref,omitempty"`
+	SubjectID int              `json:"subject_id"`
+	Status    string           `json:"status"`
+	InvoiceID int              `json:"invoice_id,omitempty"`
+	Number    string           `json:"number,omitempty"`
+	Total     *fakturoid.Money `json:"total,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	Warnings  []string         `json:"warnings,omitempty"`
+}
+
+type bulkCreateResult struct {
+	Created int              `json:"created"`
+	Failed  int              `json:"failed"`
+	Skipped int              `json:"skipped"`
+	Invalid int              `json:"invalid"`
+	Items   []bulkCreateItem `json:"items"`
+}
+
+// Item statuses of bulk operations.
+const (
+	bulkValid   = "valid"
+	bulkInvalid = "invalid"
+	bulkCreated = "created"
+	bulkFailed  = "failed"
+	bulkSkipped = "skipped"
+)
+
+func invoiceBulkCreateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var invoices []bulkInvoice
+		if raw, ok := req.GetArguments()["invoices"]; ok && raw != nil {
+			data, err := json.Marshal(raw)
+			if err == nil {
+				err = json.Unmarshal(data, &invoices)
+			}
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid invoices: %v", err)), nil
+			}
+		} else {
+			file, err := fileParam(req)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("invoices or a CSV file is required: %v", err)), nil
+			}
+			if invoices, err = parseInvoiceCSV(file.Data); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to parse CSV: %v", err)), nil
+			}
+		}
+		if len(invoices) == 0 {
+			return mcp.NewToolResultError("no invoices given"), nil
+		}
+		if len(invoices) > bulkMaxItems {
+			return mcp.NewToolResultError(fmt.Sprintf("at most %d invoices per call, got %d", bulkMaxItems, len(invoices))), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+
+		result := bulkCreateResult{Items: make([]bulkCreateItem, len(invoices))}
+		for i, inv := range invoices {
+			item := bulkCreateItem{Index: i + 1, Ref: inv.Ref, SubjectID: inv.SubjectID, Status: bulkValid}
+			if problems := validateInvoice(inv.CreateInvoiceRequest); len(problems) > 0 {
+				item.Status = bulkInvalid
+				item.Error = strings.Join(problems, "; ")
+				result.Invalid++
+			} else {
+				preview := fakturoid.PreviewInvoice(inv.Lines, previewOptions(account, inv.Currency, inv.VATPriceMode))
+				total := fakturoid.NewMoney(preview.Total, preview.Currency)
+				item.Total = &total
+				item.Warnings = preview.Warnings
+			}
+			result.Items[i] = item
+		}
+
+		if result.Invalid > 0 || req.GetBool("validate_only", false) {
+			text := bulkCreateTable(result)
+			if result.Invalid > 0 {
+				text += "\nNothing was created. Fix the invalid invoices and call again.\n"
+			} else {
+				text += "\nValidation only – call again without validate_only to create the invoices.\n"
+			}
+			return tableAndJSONResult(text, result), nil
+		}
+
+		// Exchange rates are looked up before any invoice is created, so a missing fixing
+		// does not leave the batch half done.
+		for i := range invoices {
+			if _, err := fillCreateRate(ctx, r, &invoices[i].CreateInvoiceRequest, account.Currency); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invoice %d: failed to get exchange rate (pass exchange_rate to set it): %v", i+1, err)), nil
+			}
+		}
+
+		concurrency := intParam(req, "concurrency", bulkDefaultConcurrency)
+		concurrency = max(1, min(concurrency, bulkMaxConcurrency))
+		stopOnError := req.GetBool("stop_on_error", false)
+
+		outcomes := runBulk(ctx, len(invoices), concurrency, stopOnError, func(i int) error {
+			invoice, err := retryRateLimited(ctx, func() (*fakturoid.Invoice, error) {
+				return r.client.CreateInvoice(invoices[i].CreateInvoiceRequest)
+			})
+			if err != nil {
+				return err
+			}
+			item := &result.Items[i]
+			item.InvoiceID = invoice.ID
+			item.Number = invoice.Number
+			total := invoice.TotalMoney()
+			item.Total = &total
+			return nil
+		})
+		for i, outcome := range outcomes {
+			result.Items[i].Status, result.Items[i].Error = outcome.status(bulkCreated)
+		}
+
+		for _, item := range result.Items {
+			switch item.Status {
+			case bulkCreated:
+				result.Created++
+			case bulkFailed:
+				result.Failed++
+			case bulkSkipped:
+				result.Skipped++
+			}
+		}
+		return tableAndJSONResult(bulkCreateTable(result), result), nil
+	}
+}
+
+// bulkOutcome is the result of one item of runBulk.
+type bulkOutcome struct {
+	err     error
+	skipped bool
+}
+
+// status returns the item status and error message, with done as the success status.
+func (o bulkOutcome) status(done string) (string, string) {
+	switch {
+	case o.skipped:
+		return bulkSkipped, ""
+	case o.err != nil:
+		return bulkFailed, o.err.Error()
+	}
+	return done, ""
+}
+
+// runBulk calls fn for items 0..n-1, at most concurrency at a time. With stopOnError,
+// items not started before the first failure are skipped, as are all items once ctx is done.
+func runBulk(ctx context.Context, n, concurrency int, stopOnError bool, fn func(i int) error) []bulkOutcome {
+	outcomes := make([]bulkOutcome, n)
+	var (
+		mu      sync.Mutex
+		stopped bool
+		wg      sync.WaitGroup
+		next    = make(chan int)
+	)
+	for range max(1, concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				mu.Lock()
+				skip := stopped || ctx.Err() != nil
+				mu.Unlock()
+				if skip {
+					outcomes[i].skipped = true
+					continue
+				}
+				if err := fn(i); err != nil {
+					outcomes[i].err = err
+					if stopOnError {
+						mu.Lock()
+						stopped = true
+						mu.Unlock()
+					}
+				}
+			}
+		}()
+	}
+	for i := range n {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return outcomes
+}
+
+// retryRateLimited calls fn, waiting and retrying when Fakturoid's rate limit is hit.
+func retryRateLimited[T any](ctx context.Context, fn func() (T, error)) (T, error) {
+	delay := bulkRetryDelay
+	for attempt := 0; ; attempt++ {
+		v, err := fn()
+		if !errors.Is(err, fakturoid.ErrRateLimited) || attempt == bulkRetries {
+			return v, err
+		}
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// validateInvoice lists what Fakturoid would reject in a create request, or what would
+// make an invoice plainly wrong.
+func validateInvoice(req fakturoid.CreateInvoiceRequest) []string {
+	var problems []string
+	if req.SubjectID <= 0 {
+		problems = append(problems, "subject_id is required")
+	}
+	if len(req.Lines) == 0 {
+		problems = append(problems, "at least one line is required")
+	}
+	for i, line := range req.Lines {
+		if strings.TrimSpace(line.Name) == "" {
+			problems = append(problems, fmt.Sprintf("line %d: name is required", i+1))
+		}
+		if line.Quantity.IsZero() {
+			problems = append(problems, fmt.Sprintf("line %d: quantity is zero", i+1))
+		}
+		if line.VATRate.Sign() < 0 {
+			problems = append(problems, fmt.Sprintf("line %d: negative vat_rate", i+1))
+		}
+	}
+	dates := map[string]string{"issued_on": req.IssuedOn, "due_on": req.DueOn, "taxable_fulfillment_due": req.TaxableFulfillmentDue}
+	for _, name := range []string{"issued_on", "due_on", "taxable_fulfillment_due"} {
+		if v := dates[name]; v != "" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				problems = append(problems, fmt.Sprintf("%s %q is not YYYY-MM-DD", name, v))
+			}
+		}
+	}
+	if req.IssuedOn != "" && req.DueOn != "" && req.DueOn < req.IssuedOn {
+		problems = append(problems, "due_on is before issued_on")
+	}
+	switch req.VATPriceMode {
+	case "", fakturoid.VATPriceModeWithoutVAT, fakturoid.VATPriceModeWithVAT:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown vat_price_mode %q", req.VATPriceMode))
+	}
+	if req.ExchangeRate != nil && req.ExchangeRate.Sign() <= 0 {
+		problems = append(problems, "exchange_rate must be positive")
+	}
+	return problems
+}
+
+// invoiceCSVColumns are the columns understood in bulk create CSV files. Each row is a
+// line; consecutive rows with the same ref (or, where ref is blank, the same subject)
+// form one invoice, whose other fields are taken from its first row. A ref may not
+// reappear after another invoice.
+var invoiceCSVColumns = []string{"ref", "subject_id", "name", "quantity", "unit_name", "unit_price", "vat_rate",
+	"currency", "exchange_rate", "issued_on", "due_on", "taxable_fulfillment_due", "note", "vat_price_mode",
+	"bank_account_id", "number_format_id"}
+
+// parseInvoiceCSV reads invoices from a CSV file with a header row of invoiceCSVColumns.
+// Both comma and semicolon separators are accepted, and decimal commas in numbers.
+func parseInvoiceCSV(data []byte) ([]bulkInvoice, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	cr := csv.NewReader(bytes.NewReader(data))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+
+	record, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range record {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for name := range columns {
+		if !slices.Contains(invoiceCSVColumns, name) {
+			return nil, fmt.Errorf("unknown column %q (known: %s)", name, strings.Join(invoiceCSVColumns, ", "))
+		}
+	}
+	for _, name := range []string{"subject_id", "name", "unit_price"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("column %s is required", name)
+		}
+	}
+
+	var invoices []bulkInvoice
+	lastKey := ""
+	refs := map[string]int{}
+	for line := 2; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		amount := func(name string) (fakturoid.Amount, error) {
+			v := strings.ReplaceAll(strings.ReplaceAll(get(name), " ", ""), ",", ".")
+			a, err := fakturoid.ParseAmount(v)
+			if err != nil {
+				return a, fmt.Errorf("line %d: %s: %w", line, name, err)
+			}
+			return a, nil
+		}
+		number := func(name string) (int, error) {
+			if get(name) == "" {
+				return 0, nil
+			}
+			n, err := strconv.Atoi(get(name))
+			if err != nil {
+				return 0, fmt.Errorf("line %d: %s %q is not a number", line, name, get(name))
+			}
+			return n, nil
+		}
+
+		invoiceLine := fakturoid.InvoiceLine{Name: get("name"), UnitName: get("unit_name"), Quantity: fakturoid.NewAmount(1)}
+		if get("quantity") != "" {
+			if invoiceLine.Quantity, err = amount("quantity"); err != nil {
+				return nil, err
+			}
+		}
+		if invoiceLine.UnitPrice, err = amount("unit_price"); err != nil {
+			return nil, err
+		}
+		if invoiceLine.VATRate, err = amount("vat_rate"); err != nil {
+			return nil, err
+		}
+
+		ref := get("ref")
+		key := "ref:" + ref
+		if ref == "" {
+			key = "subject:" + get("subject_id")
+		}
+		if len(invoices) > 0 && key == lastKey {
+			last := &invoices[len(invoices)-1]
+			last.Lines = append(last.Lines, invoiceLine)
+			continue
+		}
+		lastKey = key
+		if ref != "" {
+			if first, ok := refs[ref]; ok {
+				return nil, fmt.Errorf("line %d: ref %q already used for the invoice starting on line %d; keep its rows together", line, ref, first)
+			}
+			refs[ref] = line
+		}
+
+		inv := bulkInvoice{Ref: get("ref")}
+		inv.Lines = []fakturoid.InvoiceLine{invoiceLine}
+		inv.Currency = strings.ToUpper(get("currency"))
+		inv.IssuedOn = get("issued_on")
+		inv.DueOn = get("due_on")
+		inv.TaxableFulfillmentDue = get("taxable_fulfillment_due")
+		inv.Note = get("note")
+		inv.VATPriceMode = get("vat_price_mode")
+		if inv.SubjectID, err = number("subject_id"); err != nil {
+			return nil, err
+		}
+		if inv.BankAccountID, err = number("bank_account_id"); err != nil {
+			return nil, err
+		}
+		if inv.NumberFormatID, err = number("number_format_id"); err != nil {
+			return nil, err
+		}
+		if get("exchange_rate") != "" {
+			rate, err := amount("exchange_rate")
+			if err != nil {
+				return nil, err
+			}
+			inv.ExchangeRate = &rate
+		}
+		invoices = append(invoices, inv)
+	}
+	return invoices, nil
+}
+
+func bulkCreateTable(res bulkCreateResult) string {
+	var b strings.Builder
+	if res.Invalid > 0 {
+		fmt.Fprintf(&b, "%d invoices, %d invalid\n", len(res.Items), res.Invalid)
+	} else {
+		fmt.Fprintf(&b, "%d invoices: %d created, %d failed, %d skipped\n", len(res.Items), res.Created, res.Failed, res.Skipped)
+	}
+	b.WriteString("\n| # | Ref | Subject | Status | Invoice | Total | Note |\n|---:|---|---:|---|---|---:|---|\n")
+	for _, item := range res.Items {
+		total := ""
+		if item.Total != nil {
+			total = item.Total.String()
+		}
+		note := item.Error
+		if note == "" {
+			note = strings.Join(item.Warnings, "; ")
+		}
+		fmt.Fprintf(&b, "| %d | %s | %d | %s | %s | %s | %s |\n", item.Index, item.Ref, item.SubjectID, item.Status,
+			item.Number, total, note)
+	}
+	return b.String()
+}
+
+// Bulk invoice actions.
+const (
+	actionSend     = "send"
+	actionMarkSent = "mark_sent"
+	actionLock     = "lock"
+	actionCancel   = "cancel"
+)
+
+var actionDone = map[string]string{
+	actionSend:     "sent",
+	actionMarkSent: "marked as sent",
+	actionLock:     "locked",
+	actionCancel:   "cancelled",
+}
+
+type bulkActionItem struct {
+	InvoiceID int             `json:"invoice_id"`
+	Number    string          `json:"number"`
+	Client    string          `json:"client"`
+	IssuedOn  string          `json:"issued_on"`
+	Total     fakturoid.Money `json:"total"`
+	Status    string          `json:"status"`
+	Email     string          `json:"email,omitempty"`
+	Result    string          `json:"result"`
+	Reason    string          `json:"reason,omitempty"`
+	send      *fakturoid.SendInvoiceRequest
+}
+
+type bulkActionResult struct {
+	Action       string           `json:"action"`
+	Preview      bool             `json:"preview"`
+	Confirmation string           `json:"confirmation,omitempty"`
+	Done         int              `json:"done"`
+	Failed       int              `json:"failed"`
+	Skipped      int              `json:"skipped"`
+	Items        []bulkActionItem `json:"items"`
+}
+
+// Item results of bulk actions before they are applied.
+const (
+	bulkPending       = "pending"
+	bulkNotApplicable = "not applicable"
+)
+
+func invoiceBulkActionHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		action := req.GetString("action", "")
+		if _, ok := actionDone[action]; !ok {
+			return mcp.NewToolResultError("action must be send, mark_sent, lock or cancel"), nil
+		}
+
+		invoices, err := selectInvoices(r, req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to select invoices: %v", err)), nil
+		}
+		if len(invoices) == 0 {
+			return mcp.NewToolResultText("No invoices match the selection"), nil
+		}
+		if len(invoices) > bulkMaxItems {
+			return mcp.NewToolResultError(fmt.Sprintf("%d invoices match, at most %d per call; narrow the selection", len(invoices), bulkMaxItems)), nil
+		}
+
+		var sender string
+		name := req.GetString("template", "")
+		if action == actionSend && name != "" {
+			account, err := r.client.GetAccount()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+			}
+			sender = account.Name
+		}
+
+		result := bulkActionResult{Action: action, Items: make([]bulkActionItem, len(invoices))}
+		emails := map[int]string{}
+		var applicable []int
+		for i, inv := range invoices {
+			item := bulkActionItem{
+				InvoiceID: inv.ID,
+				Number:    inv.Number,
+				Client:    report.SubjectName(inv),
+				IssuedOn:  inv.IssuedOn,
+				Total:     inv.TotalMoney(),
+				Status:    inv.Status,
+				Result:    bulkPending,
+			}
+			item.Reason = actionBlocker(action, inv)
+			if item.Reason == "" && action == actionSend {
+				email, ok := emails[inv.SubjectID]
+				if !ok {
+					subject, err := r.client.GetSubject(inv.SubjectID)
+					if err != nil {
+						return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject %d: %v", inv.SubjectID, err)), nil
+					}
+					email = subject.Email
+					emails[inv.SubjectID] = email
+				}
+				item.Email = email
+				item.send = &fakturoid.SendInvoiceRequest{Email: email}
+				if email == "" {
+					item.Reason = "subject has no email"
+				} else if name != "" {
+					if _, err := applyEmailTemplate(r, item.send, inv, name, req.GetString("language", ""), sender); err != nil {
+						return mcp.NewToolResultError(err.Error()), nil
+					}
+				}
+			}
+			if item.Reason != "" {
+				item.Result = bulkNotApplicable
+				result.Skipped++
+			} else {
+				applicable = append(applicable, i)
+			}
+			result.Items[i] = item
+		}
+
+		code := confirmationCode(action, name, req.GetString("language", ""), result.Items)
+		confirm := req.GetString("confirm", "")
+		if confirm == "" || len(applicable) == 0 {
+			result.Preview = true
+			if len(applicable) > 0 {
+				result.Confirmation = code
+			}
+			return tableAndJSONResult(bulkActionTable(result), result), nil
+		}
+		if confirm != code {
+			return mcp.NewToolResultError("The selection changed since the preview (or the confirmation code is wrong). Nothing was done; preview again."), nil
+		}
+
+		outcomes := runBulk(ctx, len(applicable), bulkDefaultConcurrency, req.GetBool("stop_on_error", false), func(n int) error {
+			item := result.Items[applicable[n]]
+			_, err := retryRateLimited(ctx, func() (struct{}, error) {
+				return struct{}{}, applyInvoiceAction(r, action, item)
+			})
+			return err
+		})
+		for n, outcome := range outcomes {
+			item := &result.Items[applicable[n]]
+			item.Result, item.Reason = outcome.status(actionDone[action])
+			switch item.Result {
+			case bulkFailed:
+				result.Failed++
+			case bulkSkipped:
+				result.Skipped++
+			default:
+				result.Done++
+			}
+		}
+		return tableAndJSONResult(bulkActionTable(result), result), nil
+	}
+}
+
+// selectInvoices returns the invoices given by invoice_ids, or those matching the filters.
+func selectInvoices(r *registry, req mcp.CallToolRequest) ([]fakturoid.Invoice, error) {
+	if ids := req.GetIntSlice("invoice_ids", nil); len(ids) > 0 {
+		if len(ids) > bulkMaxItems {
+			return nil, fmt.Errorf("at most %d invoice_ids per call", bulkMaxItems)
+		}
+		var invoices []fakturoid.Invoice
+		for _, id := range ids {
+			inv, err := r.client.GetInvoice(id)
+			if err != nil {
+				return nil, fmt.Errorf("get invoice %d: %w", id, err)
+			}
+			invoices = append(invoices, *inv)
+		}
+		return invoices, nil
+	}
+
+	from, to := req.GetString("issued_from", ""), req.GetString("issued_to", "")
+	if month := req.GetString("month", ""); month != "" {
+		first, last, err := promptMonth(month, 0)
+		if err != nil {
+			return nil, err
+		}
+		from, to = day(first), day(last)
+	}
+	for _, d := range []string{from, to} {
+		if _, err := time.Parse("2006-01-02", d); d != "" && err != nil {
+			return nil, fmt.Errorf("invalid date %q, use YYYY-MM-DD", d)
+		}
+	}
+	params := url.Values{}
+	if status := req.GetString("status", ""); status != "" {
+		params.Set("status", status)
+	}
+	if subjectID := intParam(req, "subject_id", 0); subjectID != 0 {
+		params.Set("subject_id", fmt.Sprint(subjectID))
+	}
+	if len(params) == 0 && from == "" && to == "" {
+		return nil, fmt.Errorf("select invoices with invoice_ids or at least one filter (status, subject_id, month, issued_from, issued_to)")
+	}
+
+	invoices, err := fakturoid.AllPages(func(page int) ([]fakturoid.Invoice, error) {
+		return r.client.GetInvoices(page, params)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list invoices: %w", err)
+	}
+	invoices = filter(invoices, func(inv fakturoid.Invoice) bool {
+		return (from == "" || inv.IssuedOn >= from) && (to == "" || inv.IssuedOn <= to)
+	})
+	sort.Slice(invoices, func(i, j int) bool { return invoices[i].ID < invoices[j].ID })
+	return invoices, nil
+}
+
+// actionBlocker explains why action cannot be applied to an invoice, or returns "".
+func actionBlocker(action string, inv fakturoid.Invoice) string {
+	switch {
+	case inv.Status == "cancelled":
+		return "cancelled"
+	case action == actionMarkSent && inv.Status != "open":
+		return "already " + inv.Status
+	case action == actionLock && inv.LockedAt != "":
+		return "already locked"
+	case action == actionCancel && inv.Status == "paid":
+		return "paid"
+	case action == actionCancel && inv.LockedAt != "":
+		return "locked"
+	}
+	return ""
+}
+
+func applyInvoiceAction(r *registry, action string, item bulkActionItem) error {
+	switch action {
+	case actionSend:
+		return r.client.SendInvoice(item.InvoiceID, *item.send)
+	case actionMarkSent:
+		return r.client.FireInvoiceEvent(item.InvoiceID, fakturoid.InvoiceEventMarkAsSent)
+	case actionLock:
+		return r.client.FireInvoiceEvent(item.InvoiceID, fakturoid.InvoiceEventLock)
+	case actionCancel:
+		return r.client.FireInvoiceEvent(item.InvoiceID, fakturoid.InvoiceEventCancel)
+	}
+	return fmt.Errorf("unknown action %q", action)
+}
+
+// confirmationCode identifies an action on a set of invoices, so a confirmed call applies
+// exactly what was previewed, down to the rendered email text.
+func confirmationCode(action, template, language string, items []bulkActionItem) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s", action, template, language)
+	for _, item := range items {
+		if item.Reason == "" {
+			fmt.Fprintf(h, "|%d", item.InvoiceID)
+			if item.send != nil {
+				fmt.Fprintf(h, ":%s:%s:%s", item.send.Email, item.send.Subject, item.send.Message)
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:10]
+}
+
+func bulkActionTable(res bulkActionResult) string {
+	var b strings.Builder
+	verb := actionDone[res.Action]
+	if res.Preview {
+		fmt.Fprintf(&b, "Preview: %d of %d invoices would be %s\n", len(res.Items)-res.Skipped, len(res.Items), verb)
+	} else {
+		fmt.Fprintf(&b, "%d invoices %s, %d failed, %d skipped\n", res.Done, verb, res.Failed, res.Skipped)
+	}
+	b.WriteString("\n| Invoice | Client | Issued | Total | Status | Result | Note |\n|---|---|---|---:|---|---|---|\n")
+	for _, item := range res.Items {
+		note := item.Reason
+		if note == "" && item.Email != "" {
+			note = "to " + item.Email
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n", item.Number, item.Client, item.IssuedOn, item.Total,
+			item.Status, item.Result, note)
+	}
+	if res.Confirmation != "" {
+		fmt.Fprintf(&b, "\nNothing was done yet. To apply, call again with the same selection and confirm=%q.\n", res.Confirmation)
+	}
+	return b.String()
+}
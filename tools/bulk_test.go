@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func TestParseInvoiceCSV(t *testing.T) {
+	data := "\xef\xbb\xbfref;subject_id;name;quantity;unit_price;vat_rate\n" +
+		"A;1;Konzultace;2;1 000,50;21\n" +
+		"A;1;Cestovné;;500;21\n" +
+		";2;Licence;;3000;21\n" +
+		";2;Podpora;;1000;21\n" +
+		"B;2;Školení;;8000;12\n" +
+		";1;Hosting;;300;21\n"
+	invoices, err := parseInvoiceCSV([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		ref     string
+		subject int
+		lines   int
+	}{{"A", 1, 2}, {"", 2, 2}, {"B", 2, 1}, {"", 1, 1}}
+	if len(invoices) != len(want) {
+		t.Fatalf("got %d invoices, want %d: %+v", len(invoices), len(want), invoices)
+	}
+	for i, w := range want {
+		inv := invoices[i]
+		if inv.Ref != w.ref || inv.SubjectID != w.subject || len(inv.Lines) != w.lines {
+			t.Errorf("invoice %d: ref %q, subject %d, %d lines; want %q, %d, %d", i, inv.Ref, inv.SubjectID, len(inv.Lines), w.ref, w.subject, w.lines)
+		}
+	}
+	if got := invoices[0].Lines[0].UnitPrice.String(); got != "1000.5" {
+		t.Errorf("unit price = %s, want 1000.5", got)
+	}
+}
+
+func TestParseInvoiceCSVSplitRef(t *testing.T) {
+	data := "ref,subject_id,name,unit_price,vat_rate\n" +
+		"A,1,Konzultace,1000,21\n" +
+		"B,2,Licence,3000,21\n" +
+		"A,1,Cestovné,500,21\n"
+	_, err := parseInvoiceCSV([]byte(data))
+	if err == nil || !strings.Contains(err.Error(), `line 4: ref "A"`) {
+		t.Errorf("error = %v, want a reused ref on line 4", err)
+	}
+}
+
+func TestConfirmationCodeCoversEmail(t *testing.T) {
+	items := func(subject, message string) []bulkActionItem {
+		return []bulkActionItem{
+			{InvoiceID: 1, send: &fakturoid.SendInvoiceRequest{Email: "a@example.com", Subject: subject, Message: message}},
+			{InvoiceID: 2, Reason: "subject has no email"},
+		}
+	}
+	base := confirmationCode(actionSend, "invoice", "", items("Faktura 1", "Dobrý den"))
+	if base != confirmationCode(actionSend, "invoice", "", items("Faktura 1", "Dobrý den")) {
+		t.Fatal("code is not stable")
+	}
+	for name, code := range map[string]string{
+		"language": confirmationCode(actionSend, "invoice", "en", items("Faktura 1", "Dobrý den")),
+		"subject":  confirmationCode(actionSend, "invoice", "", items("Invoice 1", "Dobrý den")),
+		"message":  confirmationCode(actionSend, "invoice", "", items("Faktura 1", "Hello")),
+	} {
+		if code == base {
+			t.Errorf("changing the %s keeps the code", name)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerCacheTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_cache_stats",
+			mcp.WithDescription("Diagnostics of the API response cache: hits, misses, revalidations and cached entries per resource"),
+			mcp.WithBoolean("clear", mcp.Description("Drop all cached responses afterwards (default false)")),
+		),
+		cacheStatsHandler(r),
+	)
+}
+
+type cacheStats struct {
+	Enabled bool   `json:"enabled"`
+	TTL     string `json:"ttl,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+	fakturoid.CacheStats
+	Cleared bool `json:"cleared,omitempty"`
+}
+
+func cacheStatsHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		cache := r.client.Cache()
+		if cache == nil {
+			return mcp.NewToolResultStructured(cacheStats{}, "The response cache is off (cache_ttl is 0)."), nil
+		}
+		stats := cacheStats{Enabled: true, TTL: cache.TTL.String(), Dir: cache.Dir, CacheStats: cache.Stats()}
+		if req.GetBool("clear", false) {
+			cache.Clear()
+			stats.Cleared = true
+		}
+		return tableAndJSONResult(cacheStatsTable(stats), stats), nil
+	}
+}
+
+func cacheStatsTable(s cacheStats) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Response cache, TTL %s", s.TTL)
+	if s.Dir != "" {
+		fmt.Fprintf(&b, ", kept in %s", s.Dir)
+	}
+	b.WriteString("\n\n")
+	requests := s.Hits + s.Misses + s.Revalidated
+	fmt.Fprintf(&b, "| Hits | Revalidated | Misses | Hit rate | Invalidations | Evictions | Entries | Fresh |\n|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	rate := "-"
+	if requests > 0 {
+		rate = fmt.Sprintf("%.0f %%", 100*float64(s.Hits+s.Revalidated)/float64(requests))
+	}
+	fmt.Fprintf(&b, "| %d | %d | %d | %s | %d | %d | %d | %d |\n", s.Hits, s.Revalidated, s.Misses, rate, s.Invalidations, s.Evictions, s.Entries, s.Fresh)
+
+	if len(s.Resources) > 0 {
+		names := make([]string, 0, len(s.Resources))
+		for name := range s.Resources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		b.WriteString("\n| Resource | Entries |\n|---|---:|\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "| %s | %d |\n", name, s.Resources[name])
+		}
+	}
+	if s.Cleared {
+		b.WriteString("\nThe cache has been cleared.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/company"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerCompanyTools(s *server.MCPServer, r *registry) {
+	if r.companies == nil {
+		return
+	}
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_subject_create_from_registry",
+			mcp.WithDescription("Look up a company by registration number (IČO) in the business registry (ARES) and create a subject from it. "+
+				"An existing subject with the same IČO or DIČ, or the one given by subject_id, is updated instead."),
+			mcp.WithString("registration_no", mcp.Required(), mcp.Description("Company registration number (IČO)")),
+			mcp.WithNumber("subject_id", mcp.Description("Subject to update (default: match by IČO/DIČ)")),
+			mcp.WithString("email", mcp.Description("Contact email, not provided by the registry")),
+			mcp.WithString("phone", mcp.Description("Contact phone, not provided by the registry")),
+			mcp.WithBoolean("preview", mcp.Description("Only look up the company and report what would change, field by field for an existing subject (default false)")),
+		),
+		subjectFromRegistryHandler(r),
+	)
+}
+
+type registrySubjectResult struct {
+	Action  string             `json:"action"`
+	Company *company.Info      `json:"company"`
+	Subject *fakturoid.Subject `json:"subject,omitempty"`
+	// Changes lists the fields an update changes on the existing subject.
+	Changes  []fieldChange `json:"changes,omitempty"`
+	Warnings []string      `json:"warnings,omitempty"`
+}
+
+type fieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+func subjectFromRegistryHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		info, err := r.companies.Lookup(ctx, req.GetString("registration_no", ""))
+		if errors.Is(err, company.ErrNotFound) {
+			return mcp.NewToolResultError(fmt.Sprintf("Company %s not found in the registry", req.GetString("registration_no", ""))), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to look up company: %v", err)), nil
+		}
+
+		var existing *fakturoid.Subject
+		if id := intParam(req, "subject_id", 0); id != 0 {
+			if existing, err = r.client.GetSubject(id); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject: %v", err)), nil
+			}
+		} else if existing, err = findSubject(r, info.RegistrationNo, info.VATNo); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to search subjects: %v", err)), nil
+		}
+
+		result := registrySubjectResult{Company: info}
+		if info.VATPayer != nil && !*info.VATPayer && info.VATNo != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s is not registered for VAT", info.VATNo))
+		}
+		preview := req.GetBool("preview", false)
+
+		if existing == nil {
+			result.Action = "create"
+			if preview {
+				return mcp.NewToolResultStructured(result, toJSON(result)), nil
+			}
+			subject, err := r.client.CreateSubject(fakturoid.CreateSubjectRequest{
+				Name:           info.Name,
+				Street:         info.Street,
+				City:           info.City,
+				Zip:            info.Zip,
+				Country:        info.Country,
+				RegistrationNo: info.RegistrationNo,
+				VATNo:          info.VATNo,
+				Email:          req.GetString("email", ""),
+				Phone:          req.GetString("phone", ""),
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to create subject: %v", err)), nil
+			}
+			result.Action = "created"
+			result.Subject = subject
+			return mcp.NewToolResultStructured(result, toJSON(result)), nil
+		}
+
+		update := fakturoid.UpdateSubjectRequest{
+			Name:           info.Name,
+			Street:         info.Street,
+			City:           info.City,
+			Zip:            info.Zip,
+			Country:        info.Country,
+			RegistrationNo: info.RegistrationNo,
+			VATNo:          info.VATNo,
+			Email:          req.GetString("email", ""),
+			Phone:          req.GetString("phone", ""),
+		}
+		result.Action = "update"
+		result.Subject = existing
+		result.Changes = subjectChanges(*existing, update)
+		if preview {
+			return mcp.NewToolResultStructured(result, toJSON(result)), nil
+		}
+		subject, err := r.client.UpdateSubject(existing.ID, update)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to update subject: %v", err)), nil
+		}
+		result.Action = "updated"
+		result.Subject = subject
+		return mcp.NewToolResultStructured(result, toJSON(result)), nil
+	}
+}
+
+// subjectChanges lists the fields update changes on subject. Empty fields of update are
+// not sent, so they change nothing.
+func subjectChanges(subject fakturoid.Subject, update fakturoid.UpdateSubjectRequest) []fieldChange {
+	var changes []fieldChange
+	for _, f := range []struct{ name, from, to string }{
+		{"name", subject.Name, update.Name},
+		{"street", subject.Street, update.Street},
+		{"city", subject.City, update.City},
+		{"zip", subject.Zip, update.Zip},
+		{"country", subject.Country, update.Country},
+		{"registration_no", subject.RegistrationNo, update.RegistrationNo},
+		{"vat_no", subject.VATNo, update.VATNo},
+		{"email", subject.Email, update.Email},
+		{"phone", subject.Phone, update.Phone},
+	} {
+		if f.to != "" && f.to != f.from {
+			changes = append(changes, fieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/company"
+)
+
+func TestSubjectFromRegistryPreview(t *testing.T) {
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /subjects/search.json": func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Get("query") != "27074358" {
+				writeJSON(w, []any{})
+				return
+			}
+			writeJSON(w, []map[string]any{{"id": 5, "name": "Alfa s.r.o.", "street": "Stará 1", "city": "Praha", "registration_no": "27074358", "email": "info@alfa.cz"}})
+		},
+		"PATCH /subjects/5.json": func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("preview updated the subject")
+		},
+		"POST /subjects.json": func(w http.ResponseWriter, _ *http.Request) {
+			t.Error("preview created a subject")
+		},
+	})
+	r.companies = company.Static{
+		"27074358": {RegistrationNo: "27074358", Name: "Alfa s.r.o.", Street: "Nová 2", City: "Praha", VATNo: "CZ27074358"},
+		"25596641": {RegistrationNo: "25596641", Name: "Beta a.s."},
+	}
+	h := subjectFromRegistryHandler(r)
+
+	res := callTool(t, h, map[string]any{"registration_no": "270 74 358", "preview": true}).StructuredContent.(registrySubjectResult)
+	want := []fieldChange{
+		{Field: "street", From: "Stará 1", To: "Nová 2"},
+		{Field: "vat_no", From: "", To: "CZ27074358"},
+	}
+	if res.Action != "update" || len(res.Changes) != len(want) {
+		t.Fatalf("action %q, changes %+v; want update with %+v", res.Action, res.Changes, want)
+	}
+	for i := range want {
+		if res.Changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, res.Changes[i], want[i])
+		}
+	}
+
+	res = callTool(t, h, map[string]any{"registration_no": "25596641", "preview": true}).StructuredContent.(registrySubjectResult)
+	if res.Action != "create" || res.Company.Name != "Beta a.s." {
+		t.Errorf("new company: action %q, company %+v", res.Action, res.Company)
+	}
+
+	if res := callTool(t, h, map[string]any{"registration_no": "12345678"}); !res.IsError {
+		t.Errorf("invalid IČO accepted: %s", resultText(res))
+	}
+}
@@ -0,0 +1,197 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerDuplicateTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_duplicate",
+			mcp.WithDescription("Create a new invoice as a copy of an existing one: subject, lines, currency, notes and payment settings, with new dates. "+
+				"By default the copy is issued today with the original's payment term; shift_months moves all dates by whole months instead. "+
+				"Lines flagged as in fakturoid_invoice_preview are reported as warnings."),
+			mcp.WithNumber("invoice_id", mcp.Required(), mcp.Description("Invoice to copy")),
+			mcp.WithString("issued_on", mcp.Description("Issue date of the copy (YYYY-MM-DD, default today); due and taxable supply dates keep their distance from it")),
+			mcp.WithNumber("shift_months", mcp.Description("Shift all dates by this many months instead of issued_on, e.g. 1 for next month (month ends stay month ends)")),
+			mcp.WithString("due_on", mcp.Description("Due date of the copy (YYYY-MM-DD)")),
+			mcp.WithString("taxable_fulfillment_due", mcp.Description("Taxable supply date of the copy (YYYY-MM-DD)")),
+			mcp.WithObject("quantities", mcp.Description("New quantities by line number, e.g. {\"1\": 12, \"3\": 0}; 0 removes the line")),
+			mcp.WithString("note", mcp.Description("Note of the copy (default: the original's note)")),
+			mcp.WithBoolean("preview", mcp.Description("Only show the invoice that would be created, with totals (default false)")),
+		),
+		invoiceDuplicateHandler(r),
+	)
+}
+
+type duplicatePreview struct {
+	Source  string                         `json:"source"`
+	Request fakturoid.CreateInvoiceRequest `json:"request"`
+	Totals  fakturoid.InvoicePreview       `json:"totals"`
+}
+
+func invoiceDuplicateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		invoiceID := intParam(req, "invoice_id", 0)
+		if invoiceID == 0 {
+			return mcp.NewToolResultError("invoice_id is required"), nil
+		}
+		source, err := r.client.GetInvoice(invoiceID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice: %v", err)), nil
+		}
+
+		createReq, err := duplicateRequest(*source, req)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if problems := validateInvoice(createReq); len(problems) > 0 {
+			return mcp.NewToolResultError("Invalid copy: " + strings.Join(problems, "; ")), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		rateNote, err := fillCreateRate(ctx, r, &createReq, account.Currency)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get exchange rate: %v", err)), nil
+		}
+
+		totals := fakturoid.PreviewInvoice(createReq.Lines, previewOptions(account, createReq.Currency, createReq.VATPriceMode))
+		if req.GetBool("preview", false) {
+			preview := duplicatePreview{
+				Source:  source.Number,
+				Request: createReq,
+				Totals:  totals,
+			}
+			text := fmt.Sprintf("Copy of %s for %s: issued %s, due %s, taxable supply %s, total %s %s. Nothing was created; call again without preview to create it.",
+				source.Number, source.ClientName, createReq.IssuedOn, createReq.DueOn, createReq.TaxableFulfillmentDue,
+				preview.Totals.Total, preview.Totals.Currency)
+			result := mcp.NewToolResultStructured(preview, text)
+			if rateNote != "" {
+				result.Content = append(result.Content, mcp.NewTextContent(rateNote))
+			}
+			return withWarnings(result, preview.Totals.Warnings), nil
+		}
+
+		invoice, err := r.client.CreateInvoice(createReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create invoice: %v", err)), nil
+		}
+		result := mcp.NewToolResultText(toJSON(invoice))
+		if rateNote != "" {
+			result.Content = append(result.Content, mcp.NewTextContent(rateNote))
+		}
+		return withWarnings(result, totals.Warnings), nil
+	}
+}
+
+// duplicateRequest copies an invoice into a create request and applies the overrides of req.
+// The exchange rate is not copied, so the copy gets the rate of its own date.
+func duplicateRequest(source fakturoid.Invoice, req mcp.CallToolRequest) (fakturoid.CreateInvoiceRequest, error) {
+	createReq := fakturoid.CreateInvoiceRequest{
+		SubjectID:      source.SubjectID,
+		Currency:       source.Currency,
+		Note:           req.GetString("note", source.Note),
+		VATPriceMode:   source.VATPriceMode,
+		BankAccountID:  source.BankAccountID,
+		NumberFormatID: source.NumberFormatID,
+		DocumentType:   source.DocumentType,
+		PaymentMethod:  source.PaymentMethod,
+		FooterNote:     source.FootNote,
+		Language:       source.Language,
+		Tags:           source.Tags,
+	}
+
+	quantities := map[int]fakturoid.Amount{}
+	if raw, ok := req.GetArguments()["quantities"].(map[string]any); ok {
+		for key, v := range raw {
+			n, err := strconv.Atoi(key)
+			if err != nil || n < 1 || n > len(source.Lines) {
+				return createReq, fmt.Errorf("quantities: no line %q (the invoice has %d lines)", key, len(source.Lines))
+			}
+			data, _ := json.Marshal(v)
+			var q fakturoid.Amount
+			if err := json.Unmarshal(data, &q); err != nil {
+				return createReq, fmt.Errorf("quantities: line %s: %w", key, err)
+			}
+			quantities[n] = q
+		}
+	}
+	for i, line := range source.Lines {
+		if q, ok := quantities[i+1]; ok {
+			if q.IsZero() {
+				continue
+			}
+			line.Quantity = q
+		}
+		if line.Inventory != nil {
+			line.InventoryItemID = line.Inventory.ItemID
+			line.Inventory = nil
+		}
+		createReq.Lines = append(createReq.Lines, line)
+	}
+
+	issued, err := time.Parse("2006-01-02", source.IssuedOn)
+	if err != nil {
+		return createReq, fmt.Errorf("invoice %s has no valid issue date", source.Number)
+	}
+	for _, name := range []string{"issued_on", "due_on", "taxable_fulfillment_due"} {
+		if v := req.GetString(name, ""); v != "" {
+			if _, err := time.Parse("2006-01-02", v); err != nil {
+				return createReq, fmt.Errorf("%s must be YYYY-MM-DD", name)
+			}
+		}
+	}
+	if months := intParam(req, "shift_months", 0); months != 0 {
+		if req.GetString("issued_on", "") != "" {
+			return createReq, fmt.Errorf("use either issued_on or shift_months, not both")
+		}
+		createReq.IssuedOn = shiftMonths(source.IssuedOn, months)
+		createReq.DueOn = shiftMonths(source.DueOn, months)
+		createReq.TaxableFulfillmentDue = shiftMonths(source.TaxableFulfillmentDue, months)
+	} else {
+		newIssued := time.Now()
+		if v := req.GetString("issued_on", ""); v != "" {
+			newIssued, _ = time.Parse("2006-01-02", v)
+		}
+		shift := func(date string) string {
+			t, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return ""
+			}
+			return day(newIssued.AddDate(0, 0, int(t.Sub(issued).Hours()/24)))
+		}
+		createReq.IssuedOn = day(newIssued)
+		createReq.DueOn = shift(source.DueOn)
+		createReq.TaxableFulfillmentDue = shift(source.TaxableFulfillmentDue)
+	}
+	createReq.DueOn = req.GetString("due_on", createReq.DueOn)
+	createReq.TaxableFulfillmentDue = req.GetString("taxable_fulfillment_due", createReq.TaxableFulfillmentDue)
+	return createReq, nil
+}
+
+// shiftMonths moves a YYYY-MM-DD date by months. The last day of a month stays the last
+// day, and days past the end of the target month are clamped to it.
+func shiftMonths(date string, months int) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return ""
+	}
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, months, 0)
+	last := first.AddDate(0, 1, -1).Day()
+	d := t.Day()
+	if d > last || t.AddDate(0, 0, 1).Day() == 1 {
+		d = last
+	}
+	return day(time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC))
+}
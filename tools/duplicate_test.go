@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func TestDuplicateRequestDates(t *testing.T) {
+	source := fakturoid.Invoice{
+		Number: "2026-0001", SubjectID: 3, IssuedOn: "2026-01-31", DueOn: "2026-02-14", TaxableFulfillmentDue: "2026-01-31",
+		Lines: []fakturoid.InvoiceLine{{Name: "Hosting", Quantity: fakturoid.NewAmount(1), UnitPrice: fakturoid.NewAmount(300)}},
+	}
+	call := func(args map[string]any) (fakturoid.CreateInvoiceRequest, error) {
+		var req mcp.CallToolRequest
+		req.Params.Arguments = args
+		return duplicateRequest(source, req)
+	}
+
+	got, err := call(map[string]any{"issued_on": "2026-03-01"})
+	if err != nil || got.IssuedOn != "2026-03-01" || got.DueOn != "2026-03-15" || got.TaxableFulfillmentDue != "2026-03-01" {
+		t.Errorf("issued_on: %s / %s / %s, %v", got.IssuedOn, got.DueOn, got.TaxableFulfillmentDue, err)
+	}
+	got, err = call(map[string]any{"shift_months": 1, "due_on": "2026-03-10"})
+	if err != nil || got.IssuedOn != "2026-02-28" || got.DueOn != "2026-03-10" || got.TaxableFulfillmentDue != "2026-02-28" {
+		t.Errorf("shift_months: %s / %s / %s, %v", got.IssuedOn, got.DueOn, got.TaxableFulfillmentDue, err)
+	}
+
+	for _, tt := range []struct {
+		args map[string]any
+		want string
+	}{
+		{map[string]any{"shift_months": 1, "issued_on": "2026-03-01"}, "either issued_on or shift_months"},
+		{map[string]any{"issued_on": "1. 3. 2026"}, "issued_on must be YYYY-MM-DD"},
+		{map[string]any{"due_on": "2026-02-30"}, "due_on must be YYYY-MM-DD"},
+		{map[string]any{"shift_months": 1, "taxable_fulfillment_due": "tomorrow"}, "taxable_fulfillment_due must be YYYY-MM-DD"},
+	} {
+		if _, err := call(tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: error %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestInvoiceDuplicateReportsWarnings(t *testing.T) {
+	created := 0
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /invoices/1.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, fakturoid.Invoice{ID: 1, Number: "2026-0001", SubjectID: 3, Currency: "CZK", IssuedOn: "2026-01-05", DueOn: "2026-01-19",
+				Lines: []fakturoid.InvoiceLine{{Name: "Hosting", Quantity: fakturoid.NewAmount(1), UnitPrice: fakturoid.NewAmount(0), VATRate: fakturoid.NewAmount(21)}}})
+		},
+		"GET /account.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"name": "Test s.r.o.", "currency": "CZK", "vat_mode": "vat_payer"})
+		},
+		"POST /invoices.json": func(w http.ResponseWriter, _ *http.Request) {
+			created++
+			writeJSON(w, map[string]any{"id": 2, "number": "2026-0002"})
+		},
+	})
+	res := callTool(t, invoiceDuplicateHandler(r), map[string]any{"invoice_id": 1})
+	if res.IsError || created != 1 {
+		t.Fatalf("copy not created (%d requests): %s", created, resultText(res))
+	}
+	if !strings.Contains(resultText(res), "Warnings:") {
+		t.Errorf("zero-priced copy created without warnings:\n%s", resultText(res))
+	}
+}
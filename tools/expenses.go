@@ -6,6 +6,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
 )
 
 func registerExpenseTools(s *server.MCPServer, r *registry) {
@@ -26,6 +27,18 @@ func registerExpenseTools(s *server.MCPServer, r *registry) {
 		),
 		expenseDetailHandler(r),
 	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_expense_pay",
+			mcp.WithDescription("Record a payment of an expense"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Expense ID")),
+			mcp.WithString("paid_on", mcp.Description("Payment date (YYYY-MM-DD, default today)")),
+			mcp.WithString("amount", mcp.Description("Paid amount (default: the remaining amount)")),
+			mcp.WithBoolean("vat_check", mcp.Description("Check the supplier's VAT number and that the expense's bank account is published for it (unreliable payers register) first; refuse to record the payment if it fails (default false)")),
+			mcp.WithBoolean("allow_unverified", mcp.Description("With vat_check, record the payment even when no register could verify the VAT number (default false)")),
+		),
+		expensePayHandler(r),
+	)
 }
 
 func expenseListHandler(r *registry) server.ToolHandlerFunc {
@@ -54,3 +67,48 @@ func expenseDetailHandler(r *registry) server.ToolHandlerFunc {
 		return mcp.NewToolResultText(toJSON(expense)), nil
 	}
 }
+
+func expensePayHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		amount, err := amountParam(req, "amount")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var warnings []string
+		if req.GetBool("vat_check", false) {
+			expense, err := r.client.GetExpense(id)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get expense: %v", err)), nil
+			}
+			vatNo := expense.SupplierVATNo
+			if vatNo == "" {
+				subject, err := r.client.GetSubject(expense.SubjectID)
+				if err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject: %v", err)), nil
+				}
+				vatNo = subject.VATNo
+			}
+			bankAccount := expense.BankAccount
+			if bankAccount == "" {
+				bankAccount = expense.IBAN
+			}
+			if warnings, err = vatPreflight(ctx, r, vatNo, bankAccount, req.GetBool("allow_unverified", false)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Payment not recorded: %v", err)), nil
+			}
+		}
+
+		payment, err := r.client.CreateExpensePayment(id, fakturoid.CreateExpensePaymentRequest{
+			PaidOn: req.GetString("paid_on", ""),
+			Amount: amount,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to record payment: %v", err)), nil
+		}
+		return withWarnings(mcp.NewToolResultText(toJSON(payment)), warnings), nil
+	}
+}
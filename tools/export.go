@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/export"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerExportTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_export",
+			mcp.WithDescription("Export all invoices, expenses or subjects matching the filters to a CSV or XLSX file in a local directory. Returns the file path and row count."),
+			mcp.WithString("entity", mcp.Required(), mcp.Description("invoices, expenses or subjects")),
+			mcp.WithString("format", mcp.Description("csv (default) or xlsx")),
+			mcp.WithArray("columns", mcp.WithStringItems(), mcp.Description("Columns to include, in order (default: a common set; an unknown name lists the available ones)")),
+			mcp.WithString("directory", mcp.Description("Output directory (default: system temp directory)")),
+			mcp.WithString("filename", mcp.Description("File name (default: <entity>-<date>.<format>)")),
+			mcp.WithBoolean("overwrite", mcp.Description("Replace an existing file of the same name (default false)")),
+			mcp.WithString("delimiter", mcp.Description("CSV field delimiter (default \",\"; use \";\" for Czech Excel)")),
+			mcp.WithBoolean("decimal_comma", mcp.Description("CSV: write numbers with a decimal comma and a UTF-8 BOM for Czech Excel")),
+			mcp.WithString("status", mcp.Description("Invoices/expenses: filter by status")),
+			mcp.WithNumber("subject_id", mcp.Description("Invoices/expenses: filter by subject ID")),
+			mcp.WithString("from", mcp.Description("Invoices/expenses: issued on or after (YYYY-MM-DD)")),
+			mcp.WithString("to", mcp.Description("Invoices/expenses: issued on or before (YYYY-MM-DD)")),
+		),
+		exportHandler(r),
+	)
+}
+
+func exportHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		entity := req.GetString("entity", "")
+		format := strings.ToLower(req.GetString("format", "csv"))
+		if format != "csv" && format != "xlsx" {
+			return mcp.NewToolResultError("format must be csv or xlsx"), nil
+		}
+		delimiter := req.GetString("delimiter", ",")
+		if delimiter == `\t` {
+			delimiter = "\t"
+		}
+		if utf8.RuneCountInString(delimiter) != 1 {
+			return mcp.NewToolResultError("delimiter must be a single character"), nil
+		}
+		from, to := req.GetString("from", ""), req.GetString("to", "")
+		for _, d := range []string{from, to} {
+			if _, err := time.Parse("2006-01-02", d); d != "" && err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid date %q (use YYYY-MM-DD)", d)), nil
+			}
+		}
+		inRange := func(date string) bool {
+			return (from == "" || date >= from) && (to == "" || (date != "" && date <= to))
+		}
+
+		params := url.Values{}
+		if status := req.GetString("status", ""); status != "" {
+			params.Set("status", status)
+		}
+		if subjectID := intParam(req, "subject_id", 0); subjectID != 0 {
+			params.Set("subject_id", fmt.Sprintf("%d", subjectID))
+		}
+		columns := req.GetStringSlice("columns", nil)
+
+		var table *export.Table
+		var err error
+		switch entity {
+		case "invoices":
+			var invoices []fakturoid.Invoice
+			invoices, err = fakturoid.AllPages(func(page int) ([]fakturoid.Invoice, error) {
+				return r.client.GetInvoices(page, params)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+			}
+			invoices = filter(invoices, func(i fakturoid.Invoice) bool { return inRange(i.IssuedOn) })
+			table, err = export.Build(invoices, export.InvoiceColumns, export.DefaultInvoiceColumns, columns)
+		case "expenses":
+			var expenses []fakturoid.Expense
+			expenses, err = fakturoid.AllPages(func(page int) ([]fakturoid.Expense, error) {
+				return r.client.GetExpenses(page, params)
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list expenses: %v", err)), nil
+			}
+			expenses = filter(expenses, func(e fakturoid.Expense) bool { return inRange(e.IssuedOn) })
+			table, err = export.Build(expenses, export.ExpenseColumns, export.DefaultExpenseColumns, columns)
+		case "subjects":
+			var subjects []fakturoid.Subject
+			subjects, err = fakturoid.AllPages(r.client.GetSubjects)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list subjects: %v", err)), nil
+			}
+			table, err = export.Build(subjects, export.SubjectColumns, export.DefaultSubjectColumns, columns)
+		default:
+			return mcp.NewToolResultError("entity must be invoices, expenses or subjects"), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		var buf bytes.Buffer
+		if format == "xlsx" {
+			err = export.WriteXLSX(&buf, table, entity)
+		} else {
+			comma, _ := utf8.DecodeRuneInString(delimiter)
+			err = export.WriteCSV(&buf, table, export.CSVOptions{Delimiter: comma, DecimalComma: req.GetBool("decimal_comma", false)})
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write %s: %v", format, err)), nil
+		}
+
+		dir := req.GetString("directory", os.TempDir())
+		filename := req.GetString("filename", fmt.Sprintf("%s-%s.%s", entity, time.Now().Format("2006-01-02"), format))
+		p, err := writeOutputFile(filepath.Join(dir, filepath.Base(filename)), "", buf.Bytes(), req.GetBool("overwrite", false))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write file: %v", err)), nil
+		}
+
+		result := map[string]any{"path": p, "rows": len(table.Rows), "columns": table.Columns}
+		return mcp.NewToolResultStructured(result, fmt.Sprintf("Exported %d %s to %s", len(table.Rows), entity, p)), nil
+	}
+}
+
+func filter[T any](items []T, keep func(T) bool) []T {
+	result := items[:0]
+	for _, item := range items {
+		if keep(item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,152 @@
+package tools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// maxUploadSize guards against accidentally uploading huge files.
+const maxUploadSize = 20 << 20
+
+type fileInput struct {
+	Filename string
+	MIMEType string
+	Data     []byte
+}
+
+func (f *fileInput) dataURL() string {
+	return fakturoid.DataURL(f.MIMEType, f.Data)
+}
+
+// fileParamOptions are the tool arguments understood by fileParam.
+var fileParamOptions = []mcp.ToolOption{
+	mcp.WithString("path", mcp.Description("Local file path")),
+	mcp.WithString("content_base64", mcp.Description("Base64 file content (alternative to path)")),
+	mcp.WithObject("resource", mcp.Description("MCP embedded resource contents {uri, mimeType, blob} or {uri, mimeType, text} (alternative to path)")),
+	mcp.WithString("filename", mcp.Description("File name (default: derived from path or resource URI)")),
+	mcp.WithString("mime_type", mcp.Description("MIME type (default: derived from file name or content)")),
+}
+
+// embeddedResource mirrors MCP blob/text resource contents.
+type embeddedResource struct {
+	URI      string `json:"uri"`
+	MIMEType string `json:"mimeType"`
+	Blob     string `json:"blob"`
+	Text     string `json:"text"`
+}
+
+// fileParam reads a file passed as a local path, base64 content or an MCP embedded resource.
+func fileParam(req mcp.CallToolRequest) (*fileInput, error) {
+	f := &fileInput{
+		Filename: req.GetString("filename", ""),
+		MIMEType: req.GetString("mime_type", ""),
+	}
+	args := req.GetArguments()
+
+	switch {
+	case req.GetString("path", "") != "":
+		p := req.GetString("path", "")
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() > maxUploadSize {
+			return nil, fmt.Errorf("file %s is larger than %d MB", p, maxUploadSize>>20)
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		f.Data = data
+		if f.Filename == "" {
+			f.Filename = filepath.Base(p)
+		}
+
+	case req.GetString("content_base64", "") != "":
+		data, err := base64.StdEncoding.DecodeString(req.GetString("content_base64", ""))
+		if err != nil {
+			return nil, fmt.Errorf("content_base64: %w", err)
+		}
+		f.Data = data
+
+	case args["resource"] != nil:
+		raw, err := json.Marshal(args["resource"])
+		if err != nil {
+			return nil, fmt.Errorf("resource: %w", err)
+		}
+		var res embeddedResource
+		if err := json.Unmarshal(raw, &res); err != nil {
+			return nil, fmt.Errorf("resource: %w", err)
+		}
+		if res.Blob != "" {
+			if f.Data, err = base64.StdEncoding.DecodeString(res.Blob); err != nil {
+				return nil, fmt.Errorf("resource blob: %w", err)
+			}
+		} else {
+			f.Data = []byte(res.Text)
+		}
+		if f.MIMEType == "" {
+			f.MIMEType = res.MIMEType
+		}
+		if f.Filename == "" && res.URI != "" {
+			f.Filename = path.Base(res.URI)
+		}
+
+	default:
+		return nil, fmt.Errorf("one of path, content_base64 or resource is required")
+	}
+
+	if len(f.Data) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+	if len(f.Data) > maxUploadSize {
+		return nil, fmt.Errorf("file is larger than %d MB", maxUploadSize>>20)
+	}
+	if f.Filename == "" {
+		f.Filename = "attachment"
+	}
+	if f.MIMEType == "" {
+		f.MIMEType = mime.TypeByExtension(strings.ToLower(filepath.Ext(f.Filename)))
+	}
+	if f.MIMEType == "" {
+		f.MIMEType = http.DetectContentType(f.Data)
+	}
+	return f, nil
+}
+
+// writeOutputFile writes data to p. When p is an existing directory, the file is created
+// in it under defaultName. An existing file is only replaced with overwrite. It returns the
+// path written.
+func writeOutputFile(p, defaultName string, data []byte, overwrite bool) (string, error) {
+	if info, err := os.Stat(p); err == nil && info.IsDir() {
+		p = filepath.Join(p, defaultName)
+	}
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(p, flags, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%s already exists; pass overwrite=true to replace it", p)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return "", err
+	}
+	return p, f.Close()
+}
@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteOutputFile(t *testing.T) {
+	dir := t.TempDir()
+
+	written, err := writeOutputFile(dir, "invoice.isdoc", []byte("first"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != filepath.Join(dir, "invoice.isdoc") {
+		t.Errorf("wrote %s, want the default name in the directory", written)
+	}
+
+	if _, err := writeOutputFile(written, "", []byte("second"), false); err == nil || !strings.Contains(err.Error(), "overwrite=true") {
+		t.Errorf("replacing without overwrite: error = %v", err)
+	}
+	if data, _ := os.ReadFile(written); string(data) != "first" {
+		t.Errorf("file changed to %q without overwrite", data)
+	}
+
+	if _, err := writeOutputFile(written, "", []byte("2nd"), true); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(written); string(data) != "2nd" {
+		t.Errorf("file = %q after overwrite, want 2nd", data)
+	}
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/tedyno/fakturoid-mcp/fakturoid"
@@ -34,3 +35,51 @@ func parseInvoiceLines(raw any) ([]fakturoid.InvoiceLine, error) {
 	}
 	return lines, nil
 }
+
+// amountParam reads a decimal argument given either as a JSON number or a string.
+// It returns nil when the argument is absent.
+func amountParam(req mcp.CallToolRequest, name string) (*fakturoid.Amount, error) {
+	raw, ok := req.GetArguments()[name]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	var a fakturoid.Amount
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &a, nil
+}
+
+// optionalBool returns nil when the argument is absent, so updates leave the field untouched.
+func optionalBool(req mcp.CallToolRequest, name string) *bool {
+	if _, ok := req.GetArguments()[name]; !ok {
+		return nil
+	}
+	v := req.GetBool(name, false)
+	return &v
+}
+
+// amountParams reads several optional decimal arguments, keyed by argument name.
+func amountParams(req mcp.CallToolRequest, names ...string) (map[string]*fakturoid.Amount, error) {
+	amounts := make(map[string]*fakturoid.Amount, len(names))
+	for _, name := range names {
+		a, err := amountParam(req, name)
+		if err != nil {
+			return nil, err
+		}
+		amounts[name] = a
+	}
+	return amounts, nil
+}
+
+// parseTimeParam accepts RFC 3339 timestamps and plain YYYY-MM-DD dates (local midnight).
+func parseTimeParam(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", v, time.Local)
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerInboxTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_inbox_file_list",
+			mcp.WithDescription("List files in the Fakturoid inbox (paginated, 40 per page)"),
+			mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
+		),
+		inboxFileListHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inbox_file_upload",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Upload a file (e.g. a supplier's PDF bill) to the Fakturoid inbox. Pass path, content_base64 or resource."),
+				mcp.WithBoolean("send_to_ocr", mcp.Description("Send the file to OCR to pre-fill an expense (default false)")),
+			}, fileParamOptions...)...,
+		),
+		inboxFileUploadHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inbox_file_send_to_ocr",
+			mcp.WithDescription("Send an inbox file to OCR"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Inbox file ID")),
+		),
+		inboxFileSendToOCRHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inbox_file_delete",
+			mcp.WithDescription("Delete an inbox file"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Inbox file ID")),
+		),
+		inboxFileDeleteHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_expense_attach",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Attach a file to an expense. Pass path, content_base64 or resource."),
+				mcp.WithNumber("id", mcp.Required(), mcp.Description("Expense ID")),
+			}, fileParamOptions...)...,
+		),
+		expenseAttachHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_attach",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Attach a file to an invoice. Pass path, content_base64 or resource."),
+				mcp.WithNumber("id", mcp.Required(), mcp.Description("Invoice ID")),
+			}, fileParamOptions...)...,
+		),
+		invoiceAttachHandler(r),
+	)
+}
+
+func inboxFileListHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		page := intParam(req, "page", 1)
+
+		files, err := r.client.GetInboxFiles(page)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list inbox files: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(files)), nil
+	}
+}
+
+func inboxFileUploadHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		file, err := fileParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid file: %v", err)), nil
+		}
+
+		createReq := fakturoid.CreateInboxFileRequest{
+			Attachment: file.dataURL(),
+			Filename:   file.Filename,
+			SendToOCR:  req.GetBool("send_to_ocr", false),
+		}
+
+		inboxFile, err := r.client.CreateInboxFile(createReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to upload inbox file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(inboxFile)), nil
+	}
+}
+
+func inboxFileSendToOCRHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		err := r.client.SendInboxFileToOCR(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to send inbox file to OCR: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Inbox file %d sent to OCR", id)), nil
+	}
+}
+
+func inboxFileDeleteHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		err := r.client.DeleteInboxFile(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete inbox file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Inbox file %d deleted", id)), nil
+	}
+}
+
+func expenseAttachHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		file, err := fileParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid file: %v", err)), nil
+		}
+
+		updateReq := fakturoid.UpdateExpenseRequest{
+			Attachments: []fakturoid.AttachmentUpload{{Filename: file.Filename, DataURL: file.dataURL()}},
+		}
+
+		expense, err := r.client.UpdateExpense(id, updateReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to attach file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(expense)), nil
+	}
+}
+
+func invoiceAttachHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		file, err := fileParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid file: %v", err)), nil
+		}
+
+		updateReq := fakturoid.UpdateInvoiceRequest{
+			Attachments: []fakturoid.AttachmentUpload{{Filename: file.Filename, DataURL: file.dataURL()}},
+		}
+
+		invoice, err := r.client.UpdateInvoice(id, updateReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to attach file: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(invoice)), nil
+	}
+}
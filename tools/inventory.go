@@ -0,0 +1,373 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerInventoryTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_list",
+			mcp.WithDescription("List inventory items (paginated, 40 per page)"),
+			mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
+			mcp.WithString("filter", mcp.Description("archived or low_quantity (default: active items)")),
+			mcp.WithString("sku", mcp.Description("Filter by SKU")),
+			mcp.WithString("article_number", mcp.Description("Filter by article number")),
+		),
+		inventoryItemListHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_search",
+			mcp.WithDescription("Search inventory items by name, SKU or article number"),
+			mcp.WithString("query", mcp.Required(), mcp.Description("Search query")),
+			mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
+		),
+		inventoryItemSearchHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_detail",
+			mcp.WithDescription("Get full detail of an inventory item including stock quantity"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Inventory item ID")),
+		),
+		inventoryItemDetailHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_create",
+			mcp.WithDescription("Create a new inventory item"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Item name")),
+			mcp.WithString("sku", mcp.Description("Stock keeping unit")),
+			mcp.WithString("article_number_type", mcp.Description("ian, ean or isbn")),
+			mcp.WithString("article_number", mcp.Description("Article number")),
+			mcp.WithString("unit_name", mcp.Description("Unit name (e.g. ks, kg)")),
+			mcp.WithBoolean("track_quantity", mcp.Description("Track stock quantity")),
+			mcp.WithNumber("quantity", mcp.Description("Initial quantity in stock (requires track_quantity)")),
+			mcp.WithNumber("min_quantity", mcp.Description("Low-stock threshold")),
+			mcp.WithNumber("max_quantity", mcp.Description("Maximum stock quantity")),
+			mcp.WithBoolean("allow_below_zero", mcp.Description("Allow stock to go below zero")),
+			mcp.WithNumber("purchase_price", mcp.Description("Purchase price in account currency")),
+			mcp.WithNumber("retail_price", mcp.Description("Retail price in account currency")),
+			mcp.WithString("vat_rate", mcp.Description("VAT rate: standard, reduced, reduced2 or zero")),
+			mcp.WithString("supply_type", mcp.Description("goods or service")),
+			mcp.WithString("private_note", mcp.Description("Private note")),
+		),
+		inventoryItemCreateHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_update",
+			mcp.WithDescription("Update an inventory item. Use fakturoid_inventory_move_create to change stock quantity."),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Inventory item ID")),
+			mcp.WithString("name", mcp.Description("Item name")),
+			mcp.WithString("sku", mcp.Description("Stock keeping unit")),
+			mcp.WithString("article_number_type", mcp.Description("ian, ean or isbn")),
+			mcp.WithString("article_number", mcp.Description("Article number")),
+			mcp.WithString("unit_name", mcp.Description("Unit name (e.g. ks, kg)")),
+			mcp.WithBoolean("track_quantity", mcp.Description("Track stock quantity")),
+			mcp.WithNumber("min_quantity", mcp.Description("Low-stock threshold")),
+			mcp.WithNumber("max_quantity", mcp.Description("Maximum stock quantity")),
+			mcp.WithBoolean("allow_below_zero", mcp.Description("Allow stock to go below zero")),
+			mcp.WithNumber("purchase_price", mcp.Description("Purchase price in account currency")),
+			mcp.WithNumber("retail_price", mcp.Description("Retail price in account currency")),
+			mcp.WithString("vat_rate", mcp.Description("VAT rate: standard, reduced, reduced2 or zero")),
+			mcp.WithString("supply_type", mcp.Description("goods or service")),
+			mcp.WithString("private_note", mcp.Description("Private note")),
+		),
+		inventoryItemUpdateHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_item_archive",
+			mcp.WithDescription("Archive (or unarchive) an inventory item"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Inventory item ID")),
+			mcp.WithBoolean("unarchive", mcp.Description("Restore an archived item instead (default false)")),
+		),
+		inventoryItemArchiveHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_stock",
+			mcp.WithDescription("Stock levels of all tracked inventory items (pages through everything)"),
+			mcp.WithBoolean("low_only", mcp.Description("Only items at or below their minimum quantity")),
+		),
+		inventoryStockHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_move_list",
+			mcp.WithDescription("List stock moves (paginated, 40 per page)"),
+			mcp.WithNumber("page", mcp.Description("Page number (default 1)")),
+			mcp.WithNumber("inventory_item_id", mcp.Description("Filter by inventory item ID")),
+		),
+		inventoryMoveListHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_inventory_move_create",
+			mcp.WithDescription("Record a stock move (receipt or issue) for an inventory item. Invoice lines with inventory_item_id create moves automatically."),
+			mcp.WithNumber("inventory_item_id", mcp.Required(), mcp.Description("Inventory item ID")),
+			mcp.WithString("direction", mcp.Required(), mcp.Description("in (stock receipt) or out (stock issue)")),
+			mcp.WithNumber("quantity", mcp.Required(), mcp.Description("Quantity moved (positive)")),
+			mcp.WithString("moved_on", mcp.Description("Move date (YYYY-MM-DD, default today)")),
+			mcp.WithNumber("purchase_price", mcp.Description("Purchase price per unit (for receipts)")),
+			mcp.WithString("purchase_currency", mcp.Description("Purchase price currency")),
+			mcp.WithNumber("retail_price", mcp.Description("Retail price per unit (for issues)")),
+			mcp.WithString("retail_currency", mcp.Description("Retail price currency")),
+			mcp.WithString("private_note", mcp.Description("Private note")),
+		),
+		inventoryMoveCreateHandler(r),
+	)
+}
+
+func inventoryItemListHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		page := intParam(req, "page", 1)
+		filter := req.GetString("filter", "")
+		if filter != "" && filter != "archived" && filter != "low_quantity" {
+			return mcp.NewToolResultError("filter must be archived or low_quantity"), nil
+		}
+		params := url.Values{}
+		if sku := req.GetString("sku", ""); sku != "" {
+			params.Set("sku", sku)
+		}
+		if number := req.GetString("article_number", ""); number != "" {
+			params.Set("article_number", number)
+		}
+
+		items, err := r.client.GetInventoryItems(page, filter, params)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list inventory items: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(items)), nil
+	}
+}
+
+func inventoryItemSearchHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query := req.GetString("query", "")
+		if query == "" {
+			return mcp.NewToolResultError("query is required"), nil
+		}
+		page := intParam(req, "page", 1)
+
+		items, err := r.client.SearchInventoryItems(query, page)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to search inventory items: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(items)), nil
+	}
+}
+
+func inventoryItemDetailHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		item, err := r.client.GetInventoryItem(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get inventory item: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(item)), nil
+	}
+}
+
+func inventoryItemCreateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name := req.GetString("name", "")
+		if name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		amounts, err := amountParams(req, "quantity", "min_quantity", "max_quantity", "purchase_price", "retail_price")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid number: %v", err)), nil
+		}
+
+		createReq := fakturoid.CreateInventoryItemRequest{
+			Name:                name,
+			SKU:                 req.GetString("sku", ""),
+			ArticleNumberType:   req.GetString("article_number_type", ""),
+			ArticleNumber:       req.GetString("article_number", ""),
+			UnitName:            req.GetString("unit_name", ""),
+			TrackQuantity:       req.GetBool("track_quantity", false),
+			Quantity:            amounts["quantity"],
+			MinQuantity:         amounts["min_quantity"],
+			MaxQuantity:         amounts["max_quantity"],
+			AllowBelowZero:      req.GetBool("allow_below_zero", false),
+			NativePurchasePrice: amounts["purchase_price"],
+			NativeRetailPrice:   amounts["retail_price"],
+			VATRate:             req.GetString("vat_rate", ""),
+			SupplyType:          req.GetString("supply_type", ""),
+			PrivateNote:         req.GetString("private_note", ""),
+		}
+
+		item, err := r.client.CreateInventoryItem(createReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create inventory item: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(item)), nil
+	}
+}
+
+func inventoryItemUpdateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		amounts, err := amountParams(req, "min_quantity", "max_quantity", "purchase_price", "retail_price")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid number: %v", err)), nil
+		}
+
+		updateReq := fakturoid.UpdateInventoryItemRequest{
+			Name:                req.GetString("name", ""),
+			SKU:                 req.GetString("sku", ""),
+			ArticleNumberType:   req.GetString("article_number_type", ""),
+			ArticleNumber:       req.GetString("article_number", ""),
+			UnitName:            req.GetString("unit_name", ""),
+			TrackQuantity:       optionalBool(req, "track_quantity"),
+			MinQuantity:         amounts["min_quantity"],
+			MaxQuantity:         amounts["max_quantity"],
+			AllowBelowZero:      optionalBool(req, "allow_below_zero"),
+			NativePurchasePrice: amounts["purchase_price"],
+			NativeRetailPrice:   amounts["retail_price"],
+			VATRate:             req.GetString("vat_rate", ""),
+			SupplyType:          req.GetString("supply_type", ""),
+			PrivateNote:         req.GetString("private_note", ""),
+		}
+
+		item, err := r.client.UpdateInventoryItem(id, updateReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to update inventory item: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(item)), nil
+	}
+}
+
+func inventoryItemArchiveHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		var item *fakturoid.InventoryItem
+		var err error
+		if req.GetBool("unarchive", false) {
+			item, err = r.client.UnarchiveInventoryItem(id)
+		} else {
+			item, err = r.client.ArchiveInventoryItem(id)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to archive inventory item: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(item)), nil
+	}
+}
+
+type stockLevel struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	SKU         string           `json:"sku,omitempty"`
+	UnitName    string           `json:"unit_name,omitempty"`
+	Quantity    fakturoid.Amount `json:"quantity"`
+	MinQuantity fakturoid.Amount `json:"min_quantity"`
+	Low         bool             `json:"low"`
+}
+
+func inventoryStockHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		lowOnly := req.GetBool("low_only", false)
+
+		items, err := fakturoid.AllPages(func(page int) ([]fakturoid.InventoryItem, error) {
+			return r.client.GetInventoryItems(page, "", nil)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list inventory items: %v", err)), nil
+		}
+
+		levels := []stockLevel{}
+		for _, item := range items {
+			if !item.TrackQuantity {
+				continue
+			}
+			low := item.Quantity.Cmp(item.MinQuantity) <= 0
+			if lowOnly && !low {
+				continue
+			}
+			levels = append(levels, stockLevel{
+				ID:          item.ID,
+				Name:        item.Name,
+				SKU:         item.SKU,
+				UnitName:    item.UnitName,
+				Quantity:    item.Quantity,
+				MinQuantity: item.MinQuantity,
+				Low:         low,
+			})
+		}
+		return mcp.NewToolResultText(toJSON(levels)), nil
+	}
+}
+
+func inventoryMoveListHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		page := intParam(req, "page", 1)
+		itemID := intParam(req, "inventory_item_id", 0)
+
+		moves, err := r.client.GetInventoryMoves(page, itemID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list inventory moves: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(moves)), nil
+	}
+}
+
+func inventoryMoveCreateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		itemID := intParam(req, "inventory_item_id", 0)
+		if itemID == 0 {
+			return mcp.NewToolResultError("inventory_item_id is required"), nil
+		}
+		direction := req.GetString("direction", "")
+		if direction != "in" && direction != "out" {
+			return mcp.NewToolResultError("direction must be in or out"), nil
+		}
+
+		amounts, err := amountParams(req, "quantity", "purchase_price", "retail_price")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid number: %v", err)), nil
+		}
+		quantity := amounts["quantity"]
+		if quantity == nil || quantity.Sign() <= 0 {
+			return mcp.NewToolResultError("quantity must be a positive number"), nil
+		}
+
+		moveReq := fakturoid.CreateInventoryMoveRequest{
+			Direction:        direction,
+			MovedOn:          req.GetString("moved_on", ""),
+			QuantityChange:   *quantity,
+			PurchasePrice:    amounts["purchase_price"],
+			PurchaseCurrency: req.GetString("purchase_currency", ""),
+			RetailPrice:      amounts["retail_price"],
+			RetailCurrency:   req.GetString("retail_currency", ""),
+			PrivateNote:      req.GetString("private_note", ""),
+		}
+
+		move, err := r.client.CreateInventoryMove(itemID, moveReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create inventory move: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(move)), nil
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/emailtpl"
 	"github.com/tedyno/fakturoid-mcp/fakturoid"
 )
 
@@ -41,17 +43,34 @@ func registerInvoiceTools(s *server.MCPServer, r *registry) {
 
 	s.AddTool(
 		mcp.NewTool("fakturoid_invoice_create",
-			mcp.WithDescription("Create a new invoice"),
+			mcp.WithDescription("Create a new invoice. Lines are checked with the same calculation as fakturoid_invoice_preview; lines it flags are reported as warnings with the created invoice."),
 			mcp.WithNumber("subject_id", mcp.Required(), mcp.Description("Subject (contact) ID")),
-			mcp.WithArray("lines", mcp.Required(), mcp.Description("Invoice lines (array of {name, quantity, unit_price, vat_rate, unit_name})")),
+			mcp.WithArray("lines", mcp.Required(), mcp.Description("Invoice lines (array of {name, quantity, unit_price, vat_rate, unit_name, inventory_item_id}); inventory_item_id issues the quantity from stock")),
 			mcp.WithString("currency", mcp.Description("Currency code (default: account currency)")),
+			mcp.WithString("exchange_rate", mcp.Description("Account currency units per one unit of currency (default for a foreign currency: CNB fixing of the taxable supply date)")),
 			mcp.WithString("note", mcp.Description("Invoice note")),
 			mcp.WithString("due_on", mcp.Description("Due date (YYYY-MM-DD)")),
 			mcp.WithString("issued_on", mcp.Description("Issue date (YYYY-MM-DD)")),
+			mcp.WithString("taxable_fulfillment_due", mcp.Description("Taxable supply date (YYYY-MM-DD, default: issue date)")),
+			mcp.WithString("vat_price_mode", mcp.Description("without_vat (prices exclude VAT) or from_total_with_vat (prices include VAT); default: account setting")),
+			mcp.WithNumber("bank_account_id", mcp.Description("Bank account ID (see fakturoid_bank_accounts; default: account default)")),
+			mcp.WithNumber("number_format_id", mcp.Description("Number format ID (see fakturoid_number_formats; default: account default)")),
+			mcp.WithBoolean("vat_check", mcp.Description("Validate the subject's VAT number (VIES, unreliable payers) first and refuse to create the invoice if it fails (default false)")),
+			mcp.WithBoolean("allow_unverified", mcp.Description("With vat_check, create the invoice even when no register could verify the VAT number (default false)")),
 		),
 		invoiceCreateHandler(r),
 	)
 
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_preview",
+			mcp.WithDescription("Calculate subtotal, VAT per rate, rounding and total for invoice lines without creating anything. Flags suspicious lines (zero prices, unknown VAT rates)."),
+			mcp.WithArray("lines", mcp.Required(), mcp.Description("Invoice lines (array of {name, quantity, unit_price, vat_rate, unit_name})")),
+			mcp.WithString("currency", mcp.Description("Currency code (default: account currency)")),
+			mcp.WithString("vat_price_mode", mcp.Description("without_vat (prices exclude VAT) or from_total_with_vat (prices include VAT); default: account setting")),
+		),
+		invoicePreviewHandler(r),
+	)
+
 	s.AddTool(
 		mcp.NewTool("fakturoid_invoice_delete",
 			mcp.WithDescription("Delete an invoice"),
@@ -62,12 +81,16 @@ func registerInvoiceTools(s *server.MCPServer, r *registry) {
 
 	s.AddTool(
 		mcp.NewTool("fakturoid_invoice_send",
-			mcp.WithDescription("Send an invoice via email. Uses #link# in message to insert invoice link."),
+			mcp.WithDescription("Send an invoice via email. Uses #link# in message to insert invoice link. "+
+				"With template, the subject and message come from a local email template (built-in: invoice, proforma)."),
 			mcp.WithNumber("invoice_id", mcp.Required(), mcp.Description("Invoice ID")),
 			mcp.WithString("email", mcp.Required(), mcp.Description("Recipient email address")),
 			mcp.WithString("email_copy", mcp.Description("CC email address")),
-			mcp.WithString("subject", mcp.Description("Email subject (Fakturoid uses default if empty)")),
-			mcp.WithString("message", mcp.Description("Email body (use #link# for invoice link, Fakturoid uses default if empty)")),
+			mcp.WithString("subject", mcp.Description("Email subject (Fakturoid uses default if empty; overrides the template)")),
+			mcp.WithString("message", mcp.Description("Email body (use #link# for invoice link, Fakturoid uses default if empty; overrides the template)")),
+			mcp.WithString("template", mcp.Description("Email template name")),
+			mcp.WithString("language", mcp.Description("Template language, e.g. cs or en (default: configured for the subject, else by invoice language)")),
+			mcp.WithBoolean("preview", mcp.Description("Only render the email without sending (default false)")),
 		),
 		invoiceSendHandler(r),
 	)
@@ -152,20 +175,120 @@ func invoiceCreateHandler(r *registry) server.ToolHandlerFunc {
 			return mcp.NewToolResultError(fmt.Sprintf("Invalid lines: %v", err)), nil
 		}
 
+		exchangeRate, err := amountParam(req, "exchange_rate")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
 		createReq := fakturoid.CreateInvoiceRequest{
-			SubjectID: subjectID,
-			Lines:     lines,
-			Currency:  req.GetString("currency", ""),
-			Note:      req.GetString("note", ""),
-			DueOn:     req.GetString("due_on", ""),
-			IssuedOn:  req.GetString("issued_on", ""),
+			SubjectID:             subjectID,
+			Lines:                 lines,
+			Currency:              req.GetString("currency", ""),
+			ExchangeRate:          exchangeRate,
+			Note:                  req.GetString("note", ""),
+			DueOn:                 req.GetString("due_on", ""),
+			IssuedOn:              req.GetString("issued_on", ""),
+			TaxableFulfillmentDue: req.GetString("taxable_fulfillment_due", ""),
+			VATPriceMode:          req.GetString("vat_price_mode", ""),
+			BankAccountID:         intParam(req, "bank_account_id", 0),
+			NumberFormatID:        intParam(req, "number_format_id", 0),
+		}
+
+		var warnings []string
+		if req.GetBool("vat_check", false) {
+			subject, err := r.client.GetSubject(subjectID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject: %v", err)), nil
+			}
+			if warnings, err = vatPreflight(ctx, r, subject.VATNo, "", req.GetBool("allow_unverified", false)); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invoice not created: %v", err)), nil
+			}
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		preview := fakturoid.PreviewInvoice(lines, previewOptions(account, createReq.Currency, createReq.VATPriceMode))
+		warnings = append(warnings, preview.Warnings...)
+
+		rateNote, err := fillCreateRate(ctx, r, &createReq, account.Currency)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get exchange rate (pass exchange_rate to set it): %v", err)), nil
 		}
 
 		invoice, err := r.client.CreateInvoice(createReq)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to create invoice: %v", err)), nil
 		}
-		return mcp.NewToolResultText(toJSON(invoice)), nil
+		result := mcp.NewToolResultText(toJSON(invoice))
+		if rateNote != "" {
+			result.Content = append(result.Content, mcp.NewTextContent(rateNote))
+		}
+		return withWarnings(result, warnings), nil
+	}
+}
+
+// fillCreateRate sets the exchange rate of a foreign-currency invoice without one to the
+// provider's rate of its taxable supply date and describes it.
+func fillCreateRate(ctx context.Context, r *registry, req *fakturoid.CreateInvoiceRequest, accountCurrency string) (string, error) {
+	if req.Currency == "" || req.ExchangeRate != nil || r.rates == nil || strings.EqualFold(req.Currency, accountCurrency) {
+		return "", nil
+	}
+	date := req.TaxableFulfillmentDue
+	if date == "" {
+		date = req.IssuedOn
+	}
+	rate, err := rateOn(ctx, r, req.Currency, accountCurrency, date)
+	if err != nil {
+		return "", err
+	}
+	req.ExchangeRate = &rate.Rate
+	return "Exchange rate: " + rate.describe(req.Currency, accountCurrency), nil
+}
+
+func invoicePreviewHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args := req.GetArguments()
+		linesRaw, ok := args["lines"]
+		if !ok {
+			return mcp.NewToolResultError("lines is required"), nil
+		}
+
+		lines, err := parseInvoiceLines(linesRaw)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid lines: %v", err)), nil
+		}
+
+		preview, err := previewInvoice(r, lines, req.GetString("currency", ""), req.GetString("vat_price_mode", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to preview invoice: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(preview)), nil
+	}
+}
+
+// previewInvoice fills in account defaults (currency, VAT mode, country) and calculates totals.
+func previewInvoice(r *registry, lines []fakturoid.InvoiceLine, currency, vatPriceMode string) (fakturoid.InvoicePreview, error) {
+	account, err := r.client.GetAccount()
+	if err != nil {
+		return fakturoid.InvoicePreview{}, fmt.Errorf("get account: %w", err)
+	}
+	return fakturoid.PreviewInvoice(lines, previewOptions(account, currency, vatPriceMode)), nil
+}
+
+func previewOptions(account *fakturoid.Account, currency, vatPriceMode string) fakturoid.PreviewOptions {
+	if currency == "" {
+		currency = account.Currency
+	}
+	if vatPriceMode == "" {
+		vatPriceMode = account.VATPriceMode
+	}
+	return fakturoid.PreviewOptions{
+		Currency:     currency,
+		VATPriceMode: vatPriceMode,
+		Country:      account.Country,
+		VATPayer:     account.VATPayer(),
 	}
 }
 
@@ -194,6 +317,8 @@ func invoiceSendHandler(r *registry) server.ToolHandlerFunc {
 		if email == "" {
 			return mcp.NewToolResultError("email is required"), nil
 		}
+		name := req.GetString("template", "")
+		preview := req.GetBool("preview", false)
 
 		sendReq := fakturoid.SendInvoiceRequest{
 			Email:     email,
@@ -202,6 +327,27 @@ func invoiceSendHandler(r *registry) server.ToolHandlerFunc {
 			Message:   req.GetString("message", ""),
 		}
 
+		var rendered emailtpl.Email
+		if name != "" {
+			invoice, err := r.client.GetInvoice(invoiceID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice: %v", err)), nil
+			}
+			account, err := r.client.GetAccount()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+			}
+			if rendered, err = applyEmailTemplate(r, &sendReq, *invoice, name, req.GetString("language", ""), account.Name); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+
+		if preview {
+			result := invoiceEmailPreview{To: email, Copy: sendReq.EmailCopy, Template: rendered.Template, Language: rendered.Language, Subject: sendReq.Subject, Message: sendReq.Message}
+			text := fmt.Sprintf("To: %s\nSubject: %s\n\n%s\n\nNothing was sent. Call again without preview to send.", email, sendReq.Subject, sendReq.Message)
+			return mcp.NewToolResultStructured(result, text), nil
+		}
+
 		err := r.client.SendInvoice(invoiceID, sendReq)
 		if err != nil {
 			return mcp.NewToolResultError(fmt.Sprintf("Failed to send invoice: %v", err)), nil
@@ -210,6 +356,34 @@ func invoiceSendHandler(r *registry) server.ToolHandlerFunc {
 	}
 }
 
+// applyEmailTemplate renders template name for an invoice into the subject and message
+// of req that are not set yet. An empty lang picks the language for the invoice.
+func applyEmailTemplate(r *registry, req *fakturoid.SendInvoiceRequest, inv fakturoid.Invoice, name, lang, sender string) (emailtpl.Email, error) {
+	if lang == "" {
+		lang = r.emails.Language(inv)
+	}
+	rendered, err := r.emails.Render(name, lang, inv, sender)
+	if err != nil {
+		return rendered, err
+	}
+	if req.Subject == "" {
+		req.Subject = rendered.Subject
+	}
+	if req.Message == "" {
+		req.Message = rendered.Body
+	}
+	return rendered, nil
+}
+
+type invoiceEmailPreview struct {
+	To       string `json:"to"`
+	Copy     string `json:"copy,omitempty"`
+	Template string `json:"template,omitempty"`
+	Language string `json:"language,omitempty"`
+	Subject  string `json:"subject"`
+	Message  string `json:"message"`
+}
+
 func invoicePaymentsHandler(r *registry) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		invoiceID := intParam(req, "invoice_id", 0)
@@ -0,0 +1,34 @@
+package tools
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInvoiceCreateReportsPreviewWarnings(t *testing.T) {
+	created := 0
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /account.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"name": "Test s.r.o.", "currency": "CZK", "vat_mode": "vat_payer"})
+		},
+		"POST /invoices.json": func(w http.ResponseWriter, _ *http.Request) {
+			created++
+			writeJSON(w, map[string]any{"id": 9, "number": "2026-0009"})
+		},
+	})
+	res := callTool(t, invoiceCreateHandler(r), map[string]any{
+		"subject_id": 3,
+		"lines": []any{
+			map[string]any{"name": "Konzultace", "quantity": "2", "unit_price": "1000", "vat_rate": "21"},
+			map[string]any{"name": "Sleva", "quantity": "-1", "unit_price": "200", "vat_rate": "21"},
+			map[string]any{"name": "Licence", "quantity": "1", "unit_price": "500", "vat_rate": "19"},
+		},
+	})
+	if res.IsError || created != 1 {
+		t.Fatalf("invoice not created (%d requests): %s", created, resultText(res))
+	}
+	if text := resultText(res); !strings.Contains(text, "Warnings:") || !strings.Contains(text, "2026-0009") {
+		t.Errorf("result does not report the invoice with warnings:\n%s", text)
+	}
+}
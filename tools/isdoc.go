@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/isdoc"
+)
+
+const isdocMIMEType = "application/xml"
+
+func registerISDOCTools(s *server.MCPServer, r *registry) {
+	// API v3 has no ISDOC download endpoint, so documents are always generated locally.
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_export_isdoc",
+			mcp.WithDescription("Export an invoice as an ISDOC 6 e-invoice (Czech XML standard). Returned as an embedded resource, or written to disk when path is given."),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Invoice ID")),
+			mcp.WithString("path", mcp.Description("File or directory to write the .isdoc file to")),
+			mcp.WithBoolean("overwrite", mcp.Description("Replace an existing file at path (default false)")),
+		),
+		invoiceExportISDOCHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_expense_import_isdoc",
+			append([]mcp.ToolOption{
+				mcp.WithDescription("Import a supplier's ISDOC file (.isdoc, .isdocx or PDF with embedded ISDOC) as an expense. Matches the supplier by IČO/DIČ. Without create=true only a preview is returned."),
+				mcp.WithBoolean("create", mcp.Description("Create the expense (and the supplier if not found); default false shows a preview")),
+				mcp.WithBoolean("attach", mcp.Description("Attach the source file to the created expense (default true)")),
+			}, fileParamOptions...)...,
+		),
+		expenseImportISDOCHandler(r),
+	)
+}
+
+func invoiceExportISDOCHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		invoice, err := r.client.GetInvoice(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice: %v", err)), nil
+		}
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		var subject *fakturoid.Subject
+		if invoice.SubjectID != 0 {
+			subject, err = r.client.GetSubject(invoice.SubjectID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject: %v", err)), nil
+			}
+		}
+
+		doc, err := isdoc.FromInvoice(*invoice, *account, subject)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to convert invoice: %v", err)), nil
+		}
+		data, err := isdoc.Marshal(doc)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		filename := isdocFilename(invoice.Number, id)
+		if p := req.GetString("path", ""); p != "" {
+			written, err := writeOutputFile(p, filename, data, req.GetBool("overwrite", false))
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to write file: %v", err)), nil
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("ISDOC for invoice %s written to %s", invoice.Number, written)), nil
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("ISDOC for invoice %s (%s)", invoice.Number, filename)),
+				mcp.NewEmbeddedResource(mcp.TextResourceContents{
+					URI:      fmt.Sprintf("fakturoid://invoices/%d/%s", id, filename),
+					MIMEType: isdocMIMEType,
+					Text:     string(data),
+				}),
+			},
+		}, nil
+	}
+}
+
+func isdocFilename(number string, id int) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>| `, r) {
+			return '-'
+		}
+		return r
+	}, number)
+	if name == "" {
+		name = fmt.Sprintf("invoice-%d", id)
+	}
+	return name + ".isdoc"
+}
+
+// isdocImport is the result of fakturoid_expense_import_isdoc.
+type isdocImport struct {
+	*isdoc.ExpenseDraft
+	// SubjectAction is "matched" when the supplier exists, otherwise "create".
+	SubjectAction string             `json:"subject_action"`
+	Subject       *fakturoid.Subject `json:"subject,omitempty"`
+	Created       *fakturoid.Expense `json:"created,omitempty"`
+}
+
+func expenseImportISDOCHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		file, err := fileParam(req)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid file: %v", err)), nil
+		}
+		data, err := isdoc.Extract(file.Data)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		doc, err := isdoc.Unmarshal(data)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		draft, err := isdoc.ToExpense(doc)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to map ISDOC: %v", err)), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		if !sameCustomer(draft, account) {
+			draft.Warnings = append(draft.Warnings, fmt.Sprintf("document is issued to %s / %s, not to this account", draft.CustomerRegistrationNo, draft.CustomerVATNo))
+		}
+		// The document's own rate converts to the supplier's local currency, which is
+		// only usable when that is the account currency too.
+		e := &draft.Expense
+		if !strings.EqualFold(e.Currency, account.Currency) && r.rates != nil &&
+			(e.ExchangeRate == nil || !strings.EqualFold(doc.LocalCurrencyCode, account.Currency)) {
+			date := e.TaxableFulfillmentDue
+			if date == "" {
+				date = e.IssuedOn
+			}
+			rate, err := rateOn(ctx, r, e.Currency, account.Currency, date)
+			if err != nil {
+				draft.Warnings = append(draft.Warnings, fmt.Sprintf("no exchange rate for %s: %v", e.Currency, err))
+			} else {
+				e.ExchangeRate = &rate.Rate
+				draft.Warnings = append(draft.Warnings, "exchange rate "+rate.describe(e.Currency, account.Currency))
+			}
+		}
+
+		result := isdocImport{ExpenseDraft: draft, SubjectAction: "create"}
+		subject, err := findSubject(r, draft.Supplier.RegistrationNo, draft.Supplier.VATNo)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to search subjects: %v", err)), nil
+		}
+		if subject != nil {
+			result.SubjectAction = "matched"
+			result.Subject = subject
+		}
+
+		if !req.GetBool("create", false) {
+			return tableAndJSONResult(isdocImportSummary(result), result), nil
+		}
+
+		if subject == nil {
+			sup := draft.Supplier
+			subject, err = r.client.CreateSubject(fakturoid.CreateSubjectRequest{
+				Name:           sup.Name,
+				Street:         sup.Street,
+				City:           sup.City,
+				Zip:            sup.Zip,
+				Country:        sup.Country,
+				RegistrationNo: sup.RegistrationNo,
+				VATNo:          sup.VATNo,
+				Email:          sup.Email,
+				Phone:          sup.Phone,
+			})
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to create subject: %v", err)), nil
+			}
+			result.Subject = subject
+		}
+
+		createReq := draft.Expense
+		createReq.SubjectID = subject.ID
+		if req.GetBool("attach", true) {
+			createReq.Attachments = []fakturoid.AttachmentUpload{{Filename: file.Filename, DataURL: file.dataURL()}}
+		}
+		expense, err := r.client.CreateExpense(createReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create expense: %v", err)), nil
+		}
+		result.Created = expense
+		return tableAndJSONResult(isdocImportSummary(result), result), nil
+	}
+}
+
+// sameCustomer reports whether the document's customer can be the account. Documents
+// without customer identification are accepted.
+func sameCustomer(d *isdoc.ExpenseDraft, account *fakturoid.Account) bool {
+	normalize := func(s string) string { return strings.ToUpper(strings.ReplaceAll(s, " ", "")) }
+	switch {
+	case d.CustomerVATNo != "" && account.VATNo != "":
+		return normalize(d.CustomerVATNo) == normalize(account.VATNo)
+	case d.CustomerRegistrationNo != "" && account.RegistrationNo != "":
+		return normalize(d.CustomerRegistrationNo) == normalize(account.RegistrationNo)
+	}
+	return true
+}
+
+func isdocImportSummary(res isdocImport) string {
+	var b strings.Builder
+	e := res.Expense
+	sup := res.Supplier
+	fmt.Fprintf(&b, "Supplier document %s issued %s, due %s\n", e.OriginalNumber, e.IssuedOn, e.DueOn)
+	fmt.Fprintf(&b, "Supplier: %s (IČO %s, DIČ %s)", sup.Name, sup.RegistrationNo, sup.VATNo)
+	if res.Subject != nil {
+		fmt.Fprintf(&b, " – subject %d (%s)\n", res.Subject.ID, res.SubjectAction)
+	} else {
+		b.WriteString(" – new subject will be created\n")
+	}
+	b.WriteString("\n| Line | Quantity | Unit price | VAT % |\n|---|---:|---:|---:|\n")
+	for _, l := range e.Lines {
+		fmt.Fprintf(&b, "| %s | %s %s | %s | %s |\n", l.Name, l.Quantity, l.UnitName, l.UnitPrice, l.VATRate)
+	}
+	p := res.Preview
+	fmt.Fprintf(&b, "\nSubtotal %s, VAT %s, total %s %s (document: %s)\n", p.Subtotal, p.VAT, p.Total, e.Currency, res.Total)
+	if len(res.Warnings) > 0 {
+		b.WriteString("\nWarnings:\n")
+		for _, w := range res.Warnings {
+			fmt.Fprintf(&b, "- %s\n", w)
+		}
+	}
+	if res.Created != nil {
+		fmt.Fprintf(&b, "\nCreated expense %d (%s)\n", res.Created.ID, res.Created.Number)
+	} else {
+		b.WriteString("\nPreview only – call again with create=true to create the expense.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,230 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func registerPrompts(s *server.MCPServer, r *registry) {
+	s.AddPrompt(
+		mcp.NewPrompt("monthly_invoicing_run",
+			mcp.WithPromptDescription("Issue this month's invoices to regular clients, based on what was invoiced the month before"),
+			mcp.WithArgument("month", mcp.ArgumentDescription("Month to invoice (YYYY-MM, default: current month)")),
+			mcp.WithArgument("subject_id", mcp.ArgumentDescription("Limit the run to one subject")),
+		),
+		monthlyInvoicingPrompt(r),
+	)
+
+	s.AddPrompt(
+		mcp.NewPrompt("chase_overdue_invoices",
+			mcp.WithPromptDescription("Review overdue invoices and remind clients to pay"),
+			mcp.WithArgument("subject_id", mcp.ArgumentDescription("Limit to one subject")),
+			mcp.WithArgument("min_days", mcp.ArgumentDescription("Only invoices at least this many days past due (default 1)")),
+		),
+		chaseOverduePrompt(r),
+	)
+
+	s.AddPrompt(
+		mcp.NewPrompt("book_supplier_bill",
+			mcp.WithPromptDescription("Book a received supplier invoice as an expense, checking the supplier first"),
+			mcp.WithArgument("path", mcp.ArgumentDescription("Local path of the bill (ISDOC, ISDOCX or PDF with embedded ISDOC)")),
+			mcp.WithArgument("supplier", mcp.ArgumentDescription("Supplier name or IČO, when the bill has no ISDOC data")),
+		),
+		bookSupplierBillPrompt(r),
+	)
+
+	s.AddPrompt(
+		mcp.NewPrompt("month_end_close",
+			mcp.WithPromptDescription("Month-end close checklist: payments, receivables, expenses, VAT and exports"),
+			mcp.WithArgument("month", mcp.ArgumentDescription("Month to close (YYYY-MM, default: previous month)")),
+			mcp.WithArgument("statement_path", mcp.ArgumentDescription("Local path of the month's bank statement")),
+		),
+		monthEndClosePrompt(r),
+	)
+}
+
+func monthlyInvoicingPrompt(r *registry) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		from, to, err := promptMonth(args["month"], 0)
+		if err != nil {
+			return nil, err
+		}
+		prevFrom, prevTo := from.AddDate(0, -1, 0), from.AddDate(0, 0, -1)
+		scope := "all regular clients"
+		if id := args["subject_id"]; id != "" {
+			scope = "subject " + id
+		}
+
+		steps := []string{
+			"Call `fakturoid_account_info` for the account currency, VAT mode and default due days.",
+			fmt.Sprintf("List invoices issued %s – %s with `fakturoid_invoice_list`%s and group them by subject to find recurring work.", day(prevFrom), day(prevTo), subjectFilter(args)),
+			fmt.Sprintf("List invoices already issued %s – %s so no client is invoiced twice.", day(from), day(to)),
+			"For each client, propose lines based on last month and check them with `fakturoid_invoice_preview`.",
+			"Show me a table of the proposed invoices (subject, lines, total, currency) and wait for my approval.",
+			fmt.Sprintf("Create the approved invoices with `fakturoid_invoice_create`, dated %s%s.", day(to), r.vatCheckHint("for EU clients with reverse charge")),
+			"Offer to send them with `fakturoid_invoice_bulk_action` (action send, preview first) and summarise what was created and sent.",
+		}
+		return workflowPrompt(
+			"Monthly invoicing run",
+			fmt.Sprintf("Prepare the invoicing run for %s for %s.", from.Format("January 2006"), scope),
+			steps,
+		), nil
+	}
+}
+
+func chaseOverduePrompt(r *registry) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		minDays := 1
+		if v := args["min_days"]; v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("min_days must be a non-negative number")
+			}
+			minDays = n
+		}
+		filters := fmt.Sprintf("min_days=%d", minDays)
+		if id := args["subject_id"]; id != "" {
+			filters += ", subject_id=" + id
+		}
+
+		steps := []string{
+			fmt.Sprintf("Run `fakturoid_report_aging`%s and pick invoices at least %d days past due.", subjectFilter(args), minDays),
+			"Check `fakturoid_invoice_payments` for each so partially paid invoices are chased only for the rest.",
+			fmt.Sprintf("Preview the reminders with `fakturoid_invoice_remind` (%s, without send); the level escalates with days past due.", filters),
+			"Show me the drafts and wait for my approval; adjust the level or language where I ask.",
+			"Preview the approved reminders again with invoice_ids, send them with send=true and that preview's confirm code, and list what was sent to whom.",
+		}
+		return workflowPrompt(
+			"Chase overdue invoices",
+			"Find overdue invoices and prepare payment reminders.",
+			steps,
+		), nil
+	}
+}
+
+func bookSupplierBillPrompt(r *registry) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		source := "the attached bill"
+		if p := args["path"]; p != "" {
+			source = "`" + p + "`"
+		}
+
+		steps := []string{
+			fmt.Sprintf("Preview %s with `fakturoid_expense_import_isdoc` (without create) and show the supplier, lines, totals and warnings.", source),
+			"If the bill has no ISDOC data, read it, find the supplier with `fakturoid_subject_search` and collect the lines, dates, variable symbol and bank account yourself.",
+		}
+		if r.companies != nil {
+			supplier := ""
+			if s := args["supplier"]; s != "" {
+				supplier = " (" + s + ")"
+			}
+			steps = append(steps, fmt.Sprintf("If the supplier%s is not a subject yet, create it with `fakturoid_subject_create_from_registry` from its IČO.", supplier))
+		}
+		if len(r.vat) > 0 {
+			steps = append(steps, "Check the supplier's VAT number and bank account with `fakturoid_vat_check`; stop and tell me if it is an unreliable payer or the account is not published.")
+		}
+		steps = append(steps,
+			"Wait for my approval, then create the expense with `fakturoid_expense_import_isdoc` and create=true, attaching the original file.",
+			"Ask whether the bill is already paid; if so, record it with `fakturoid_expense_pay`"+r.vatCheckHint("")+".",
+		)
+		return workflowPrompt(
+			"Book supplier bill",
+			fmt.Sprintf("Book %s from a supplier as an expense.", source),
+			steps,
+		), nil
+	}
+}
+
+func monthEndClosePrompt(r *registry) server.PromptHandlerFunc {
+	return func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		args := req.Params.Arguments
+		from, to, err := promptMonth(args["month"], -1)
+		if err != nil {
+			return nil, err
+		}
+
+		statement := "Ask me for the month's bank statement, then match it"
+		if p := args["statement_path"]; p != "" {
+			statement = "Match the bank statement `" + p + "`"
+		}
+		steps := []string{
+			statement + " with `fakturoid_bank_statement_match`; record clear matches only after I confirm and list the rest for me.",
+			fmt.Sprintf("Run `fakturoid_report_aging` as of %s and flag invoices that need chasing.", day(to)),
+			fmt.Sprintf("List expenses of %s – %s with `fakturoid_expense_list` and check for missing supplier bills compared with the previous month.", day(from), day(to)),
+			fmt.Sprintf("Run `fakturoid_report_summary` for %s – %s and comment on revenue, expenses and profit against the previous month.", day(from), day(to)),
+			fmt.Sprintf("If the account is a VAT payer, run `fakturoid_report_vat_cz` for %d/%d (or the quarter when it ends) and list skipped documents.", from.Month(), from.Year()),
+			fmt.Sprintf("Export the month's invoices and expenses with `fakturoid_export` (from %s, to %s) for the accountant.", day(from), day(to)),
+			"Finish with a checklist of what is done and what still needs my attention.",
+		}
+		return workflowPrompt(
+			"Month-end close",
+			fmt.Sprintf("Close %s.", from.Format("January 2006")),
+			steps,
+		), nil
+	}
+}
+
+// workflowPrompt renders a workflow as a conversation: the request, the assistant's plan
+// and the go-ahead with the ground rules.
+func workflowPrompt(title, task string, steps []string) *mcp.GetPromptResult {
+	var plan strings.Builder
+	plan.WriteString("I'll work through these steps:\n")
+	for i, step := range steps {
+		fmt.Fprintf(&plan, "%d. %s\n", i+1, step)
+	}
+	return mcp.NewGetPromptResult(title, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(
+			"You are helping me with bookkeeping in Fakturoid through the fakturoid_* tools. "+task)),
+		mcp.NewPromptMessage(mcp.RoleAssistant, mcp.NewTextContent(plan.String())),
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(
+			"Go ahead. Ask me before anything that creates, sends, pays or deletes, and keep amounts in the document currency unless I ask otherwise.")),
+	})
+}
+
+// promptMonth returns the first and last day of a YYYY-MM month, or of the current
+// month shifted by offset when month is empty.
+func promptMonth(month string, offset int) (time.Time, time.Time, error) {
+	var first time.Time
+	if month == "" {
+		now := time.Now()
+		first = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, offset, 0)
+	} else {
+		t, err := time.ParseInLocation("2006-01", month, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("month must be YYYY-MM")
+		}
+		first = t
+	}
+	return first, first.AddDate(0, 1, -1), nil
+}
+
+func day(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func subjectFilter(args map[string]string) string {
+	if id := args["subject_id"]; id != "" {
+		return " for subject " + id
+	}
+	return ""
+}
+
+// vatCheckHint suggests the vat_check option when VAT checks are available.
+func (r *registry) vatCheckHint(when string) string {
+	if len(r.vat) == 0 {
+		return ""
+	}
+	if when == "" {
+		return " with vat_check=true"
+	}
+	return ", with vat_check=true " + when
+}
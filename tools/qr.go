@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/qrpay"
+)
+
+func registerQRTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_qr",
+			mcp.WithDescription("Payment QR code for an invoice's remaining amount: Czech QR Platba (SPAYD) or EPC/SEPA QR for EUR invoices. Returns the image and the encoded payload."),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Invoice ID")),
+			mcp.WithString("standard", mcp.Description("spayd, epc or auto (default: epc for EUR invoices, otherwise spayd)")),
+			mcp.WithString("format", mcp.Description("png (default) or svg")),
+			mcp.WithNumber("size", mcp.Description("PNG size in pixels (default 256)")),
+			mcp.WithString("message", mcp.Description("Message for the recipient (default: invoice number)")),
+		),
+		invoiceQRHandler(r),
+	)
+}
+
+func invoiceQRHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+		format := strings.ToLower(req.GetString("format", "png"))
+		if format != "png" && format != "svg" {
+			return mcp.NewToolResultError("format must be png or svg"), nil
+		}
+
+		invoice, err := r.client.GetInvoice(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice: %v", err)), nil
+		}
+		payload, standard, err := invoiceQRPayload(r, invoice, req.GetString("standard", "auto"), req.GetString("message", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		image, mimeType, err := renderQR(payload, format, intParam(req, "size", 256))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(fmt.Sprintf("%s payment code for invoice %s:\n%s", strings.ToUpper(standard), invoice.Number, payload)),
+				mcp.NewImageContent(base64.StdEncoding.EncodeToString(image), mimeType),
+			},
+		}, nil
+	}
+}
+
+// invoiceQRPayload builds the QR payload for the invoice's remaining amount and returns
+// it with the standard used.
+func invoiceQRPayload(r *registry, inv *fakturoid.Invoice, standard, message string) (string, string, error) {
+	p, err := invoicePayment(r, inv, message)
+	if err != nil {
+		return "", "", err
+	}
+	return paymentQRPayload(p, standard)
+}
+
+// paymentQRPayload encodes payment details as SPAYD or, for EUR with standard auto, EPC.
+func paymentQRPayload(p qrpay.Payment, standard string) (string, string, error) {
+	var err error
+	if standard == "" || standard == "auto" {
+		standard = "spayd"
+		if strings.EqualFold(p.Currency, "EUR") {
+			standard = "epc"
+		}
+	}
+
+	var payload string
+	switch standard {
+	case "spayd":
+		payload, err = qrpay.SPAYD(p)
+	case "epc":
+		payload, err = qrpay.EPC(p)
+	default:
+		return "", "", fmt.Errorf("standard must be spayd, epc or auto")
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("build %s payload: %w", standard, err)
+	}
+	return payload, standard, nil
+}
+
+// invoicePayment collects the payment details of an unpaid invoice. The IBAN comes from
+// the invoice, its Czech account number, or the account's default bank account.
+func invoicePayment(r *registry, inv *fakturoid.Invoice, message string) (qrpay.Payment, error) {
+	if inv.Status == "paid" || inv.Status == "cancelled" {
+		return qrpay.Payment{}, fmt.Errorf("invoice %s is %s", inv.Number, inv.Status)
+	}
+	amount := inv.RemainingAmount
+	if amount.IsZero() {
+		amount = inv.Total
+	}
+	if message == "" {
+		message = inv.Number
+	}
+	p := qrpay.Payment{
+		IBAN:           inv.IBAN,
+		BIC:            inv.SwiftBIC,
+		Amount:         amount,
+		Currency:       inv.Currency,
+		VariableSymbol: inv.VariableSymbol,
+		Message:        message,
+		Recipient:      inv.YourName,
+	}
+	if due, err := time.Parse("2006-01-02", inv.DueOn); err == nil {
+		p.DueDate = due
+	}
+
+	if p.IBAN == "" && inv.BankAccount != "" {
+		if iban, err := qrpay.CzechIBAN(inv.BankAccount); err == nil {
+			p.IBAN = iban
+		}
+	}
+	if p.IBAN == "" || p.Recipient == "" {
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return p, fmt.Errorf("get account: %w", err)
+		}
+		if p.Recipient == "" {
+			p.Recipient = account.Name
+		}
+		if p.IBAN == "" {
+			banks, err := r.client.GetBankAccounts()
+			if err != nil {
+				return p, fmt.Errorf("get bank accounts: %w", err)
+			}
+			if b := paymentBankAccount(banks, inv.Currency); b != nil {
+				p.IBAN = b.IBAN
+				if p.IBAN == "" && b.Number != "" {
+					p.IBAN, _ = qrpay.CzechIBAN(b.Number)
+				}
+				if p.BIC == "" {
+					p.BIC = b.SwiftBIC
+				}
+			}
+		}
+	}
+	if p.IBAN == "" {
+		return p, fmt.Errorf("invoice %s has no IBAN or bank account", inv.Number)
+	}
+	return p, nil
+}
+
+// paymentBankAccount prefers the default account in the invoice currency, then any
+// account in that currency, then the default account.
+func paymentBankAccount(banks []fakturoid.BankAccount, currency string) *fakturoid.BankAccount {
+	var chosen *fakturoid.BankAccount
+	for i, b := range banks {
+		if strings.EqualFold(b.Currency, currency) && (chosen == nil || b.Default) {
+			chosen = &banks[i]
+		}
+	}
+	if chosen != nil {
+		return chosen
+	}
+	for i, b := range banks {
+		if b.Default {
+			return &banks[i]
+		}
+	}
+	return nil
+}
+
+func renderQR(payload, format string, size int) ([]byte, string, error) {
+	if format == "svg" {
+		data, err := qrpay.SVG(payload)
+		return data, "image/svg+xml", err
+	}
+	if size < 64 || size > 2048 {
+		return nil, "", fmt.Errorf("size must be between 64 and 2048")
+	}
+	data, err := qrpay.PNG(payload, size)
+	return data, "image/png", err
+}
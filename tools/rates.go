@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+func registerRateTools(s *server.MCPServer, r *registry) {
+	if r.rates == nil {
+		return
+	}
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_exchange_rate",
+			mcp.WithDescription("Get the exchange rate of a currency (Czech National Bank daily fixing), optionally converting an amount"),
+			mcp.WithString("currency", mcp.Required(), mcp.Description("Currency code, e.g. EUR")),
+			mcp.WithString("to", mcp.Description("Target currency (default: account currency)")),
+			mcp.WithString("date", mcp.Description("Date (YYYY-MM-DD, default today); weekends and holidays use the last fixing before")),
+			mcp.WithString("amount", mcp.Description("Amount to convert")),
+		),
+		exchangeRateHandler(r),
+	)
+}
+
+type exchangeRateResult struct {
+	From       string            `json:"from"`
+	To         string            `json:"to"`
+	Rate       fakturoid.Amount  `json:"rate"`
+	FixingDate string            `json:"fixing_date"`
+	Source     string            `json:"source"`
+	Amount     *fakturoid.Amount `json:"amount,omitempty"`
+	Converted  *fakturoid.Amount `json:"converted,omitempty"`
+}
+
+func exchangeRateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		currency := strings.ToUpper(req.GetString("currency", ""))
+		if currency == "" {
+			return mcp.NewToolResultError("currency is required"), nil
+		}
+		amount, err := amountParam(req, "amount")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		to := strings.ToUpper(req.GetString("to", ""))
+		if to == "" {
+			account, err := r.client.GetAccount()
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+			}
+			to = strings.ToUpper(account.Currency)
+		}
+
+		rate, err := rateOn(ctx, r, currency, to, req.GetString("date", ""))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get exchange rate: %v", err)), nil
+		}
+		result := exchangeRateResult{From: currency, To: to, Rate: rate.Rate, FixingDate: rate.Date, Source: rate.Source}
+		if amount != nil {
+			converted := amount.Mul(rate.Rate).Round(fakturoid.CurrencyDecimals(to), fakturoid.RoundHalfUp)
+			result.Amount, result.Converted = amount, &converted
+		}
+		return mcp.NewToolResultStructured(result, toJSON(result)), nil
+	}
+}
+
+// providerRate is a rate with the fixing it comes from.
+type providerRate struct {
+	Rate   fakturoid.Amount
+	Date   string
+	Source string
+}
+
+// describe renders the rate as "25.34 CZK per EUR (CNB fixing of 2024-10-18)".
+func (p providerRate) describe(from, to string) string {
+	return fmt.Sprintf("%s %s per %s (%s fixing of %s)", p.Rate, strings.ToUpper(to), strings.ToUpper(from), p.Source, p.Date)
+}
+
+// rateOn returns units of to per one unit of from, valid on date (YYYY-MM-DD, empty
+// for today).
+func rateOn(ctx context.Context, r *registry, from, to, date string) (providerRate, error) {
+	if r.rates == nil {
+		return providerRate{}, fmt.Errorf("no exchange rate provider configured")
+	}
+	day := time.Now()
+	if date != "" {
+		t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			return providerRate{}, fmt.Errorf("invalid date %q", date)
+		}
+		day = t
+	}
+	fixing, err := r.rates.Fixing(ctx, day)
+	if err != nil {
+		return providerRate{}, err
+	}
+	rate, err := fixing.Rate(from, to)
+	if err != nil {
+		return providerRate{}, err
+	}
+	return providerRate{Rate: rate, Date: fixing.Date, Source: fixing.Source}, nil
+}
+
+// fillInvoiceRates sets ExchangeRate on foreign-currency invoices that have none, so
+// aggregates can express them in the account currency. The rate is derived from native
+// totals when the API sent them, otherwise taken from the provider for the day dateOf
+// returns. It returns warnings for invoices that could not be converted.
+func fillInvoiceRates(ctx context.Context, r *registry, invoices []fakturoid.Invoice, accountCurrency string, dateOf func(fakturoid.Invoice) string) []string {
+	var warnings []string
+	for i := range invoices {
+		inv := &invoices[i]
+		rate, err := missingRate(ctx, r, inv.Currency, accountCurrency, inv.ExchangeRate, inv.Total, inv.NativeTotal, dateOf(*inv))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("invoice %s: %v", inv.Number, err))
+			continue
+		}
+		if rate != nil {
+			inv.ExchangeRate = *rate
+		}
+	}
+	return warnings
+}
+
+// fillExpenseRates is fillInvoiceRates for expenses.
+func fillExpenseRates(ctx context.Context, r *registry, expenses []fakturoid.Expense, accountCurrency string, dateOf func(fakturoid.Expense) string) []string {
+	var warnings []string
+	for i := range expenses {
+		exp := &expenses[i]
+		rate, err := missingRate(ctx, r, exp.Currency, accountCurrency, exp.ExchangeRate, exp.Total, exp.NativeTotal, dateOf(*exp))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("expense %s: %v", exp.Number, err))
+			continue
+		}
+		if rate != nil {
+			exp.ExchangeRate = *rate
+		}
+	}
+	return warnings
+}
+
+// missingRate returns the rate to fill in, or nil when the document needs none.
+func missingRate(ctx context.Context, r *registry, currency, accountCurrency string, rate, total, nativeTotal fakturoid.Amount, date string) (*fakturoid.Amount, error) {
+	if currency == "" || strings.EqualFold(currency, accountCurrency) || !rate.IsZero() {
+		return nil, nil
+	}
+	if !total.IsZero() && !nativeTotal.IsZero() {
+		derived, err := nativeTotal.Div(total)
+		if err != nil {
+			return nil, err
+		}
+		return &derived, nil
+	}
+	if r.rates == nil {
+		return nil, nil
+	}
+	p, err := rateOn(ctx, r, currency, accountCurrency, date)
+	if err != nil {
+		return nil, err
+	}
+	return &p.Rate, nil
+}
+
+// invoiceRateDate is the day that decides an invoice's rate: the taxable supply date.
+func invoiceRateDate(inv fakturoid.Invoice) string {
+	if inv.TaxableFulfillmentDue != "" {
+		return inv.TaxableFulfillmentDue
+	}
+	return inv.IssuedOn
+}
+
+func expenseRateDate(exp fakturoid.Expense) string {
+	if exp.TaxableFulfillmentDue != "" {
+		return exp.TaxableFulfillmentDue
+	}
+	return exp.IssuedOn
+}
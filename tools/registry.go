@@ -2,19 +2,78 @@ package tools
 
 import (
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/company"
+	"github.com/tedyno/fakturoid-mcp/emailtpl"
+	"github.com/tedyno/fakturoid-mcp/exrate"
 	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/reminder"
+	"github.com/tedyno/fakturoid-mcp/vatcheck"
+	"github.com/tedyno/fakturoid-mcp/webhook"
 )
 
+// Options carries optional services. Tools that need a nil service are not registered.
+type Options struct {
+	// WebhookStore holds events received by the local webhook receiver.
+	WebhookStore *webhook.Store
+	// Companies looks up subjects in a business registry.
+	Companies company.Registry
+	// VATCheckers validate VAT numbers (VIES, unreliable payers).
+	VATCheckers []vatcheck.Checker
+	// Rates provides exchange rates for foreign-currency documents.
+	Rates exrate.Provider
+	// ReminderTemplates replace the built-in payment reminder templates.
+	ReminderTemplates reminder.Templates
+	// EmailTemplates replace the built-in invoice email templates.
+	EmailTemplates *emailtpl.Set
+}
+
 // RegisterAll registers all Fakturoid MCP tools on the given server.
-func RegisterAll(s *server.MCPServer, client *fakturoid.Client) {
-	r := &registry{client: client}
+func RegisterAll(s *server.MCPServer, client *fakturoid.Client, opts Options) {
+	r := &registry{
+		client:    client,
+		webhooks:  opts.WebhookStore,
+		companies: opts.Companies,
+		vat:       opts.VATCheckers,
+		rates:     opts.Rates,
+		reminders: opts.ReminderTemplates,
+		emails:    opts.EmailTemplates,
+	}
+	if r.reminders == nil {
+		r.reminders = reminder.Defaults
+	}
+	if r.emails == nil {
+		r.emails = &emailtpl.Defaults
+	}
 
 	registerAccountTools(s, r)
 	registerInvoiceTools(s, r)
 	registerSubjectTools(s, r)
 	registerExpenseTools(s, r)
+	registerInventoryTools(s, r)
+	registerInboxTools(s, r)
+	registerWebhookTools(s, r)
+	registerReportTools(s, r)
+	registerISDOCTools(s, r)
+	registerExportTools(s, r)
+	registerQRTools(s, r)
+	registerBankTools(s, r)
+	registerRemindTools(s, r)
+	registerCompanyTools(s, r)
+	registerVATTools(s, r)
+	registerRateTools(s, r)
+	registerBulkTools(s, r)
+	registerDuplicateTools(s, r)
+	registerCacheTools(s, r)
+	registerResources(s, r)
+	registerPrompts(s, r)
 }
 
 type registry struct {
-	client *fakturoid.Client
+	client    *fakturoid.Client
+	webhooks  *webhook.Store
+	companies company.Registry
+	vat       []vatcheck.Checker
+	rates     exrate.Provider
+	reminders reminder.Templates
+	emails    *emailtpl.Set
 }
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+)
+
+// testAPIPrefix is the path of the test account's endpoints.
+const testAPIPrefix = "/api/v3/accounts/test"
+
+// newTestRegistry returns a registry whose client talks to a test server. api serves the
+// account's endpoints, e.g. "GET /invoices/1.json"; the OAuth token is handled here.
+func newTestRegistry(t *testing.T, api map[string]http.HandlerFunc) *registry {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v3/oauth/token", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]any{"access_token": "token", "token_type": "Bearer", "expires_in": 7200})
+	})
+	for pattern, h := range api {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" "+testAPIPrefix+path, h)
+	}
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	target, _ := url.Parse(srv.URL)
+	client := fakturoid.NewClient("id", "secret", "test")
+	client.SetHTTPClient(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+		return http.DefaultTransport.RoundTrip(req)
+	})})
+	return &registry{client: client}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// callTool runs a handler with arguments.
+func callTool(t *testing.T, h func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	var req mcp.CallToolRequest
+	req.Params.Arguments = args
+	result, err := h(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// resultText joins the text blocks of a result.
+func resultText(result *mcp.CallToolResult) string {
+	var text string
+	for _, c := range result.Content {
+		if tc, ok := c.(mcp.TextContent); ok {
+			text += tc.Text + "\n"
+		}
+	}
+	return text
+}
@@ -0,0 +1,299 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/reminder"
+	"github.com/tedyno/fakturoid-mcp/report"
+)
+
+// maxReminderQRCodes limits the QR images attached to a preview.
+const maxReminderQRCodes = 10
+
+func registerRemindTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_invoice_remind",
+			mcp.WithDescription("Draft payment reminders for overdue invoices and send them through Fakturoid. "+
+				"Each email carries the payment QR code as a link to the invoice's public page, which shows it; the preview also shows "+
+				"the QR codes of up to 10 invoices as images and warns about emails without the link. "+
+				"The preview returns a confirmation code; send=true with confirm sends exactly the previewed reminders. "+
+				"The level escalates with days past due (reminder, second reminder, final notice)."),
+			mcp.WithNumber("invoice_id", mcp.Description("Remind a single invoice")),
+			mcp.WithNumber("subject_id", mcp.Description("Only invoices of this subject")),
+			mcp.WithNumber("min_days", mcp.Description("Only invoices at least this many days past due (default 1)")),
+			mcp.WithArray("invoice_ids", mcp.WithNumberItems(), mcp.Description("Only these invoice IDs, e.g. the approved part of a preview")),
+			mcp.WithString("language", mcp.Description("cs, en or auto (default: by invoice language and client country)")),
+			mcp.WithNumber("level", mcp.Description("Force an escalation level (1 = reminder, 2 = second reminder, 3 = final notice)")),
+			mcp.WithString("email", mcp.Description("Recipient (default: the subject's email; only with invoice_id)")),
+			mcp.WithString("email_copy", mcp.Description("Copy recipient")),
+			mcp.WithBoolean("send", mcp.Description("Send the reminders (default false: preview only); requires confirm")),
+			mcp.WithString("confirm", mcp.Description("Confirmation code from the preview")),
+		),
+		invoiceRemindHandler(r),
+	)
+}
+
+type invoiceReminder struct {
+	InvoiceID   int             `json:"invoice_id"`
+	Number      string          `json:"number"`
+	Client      string          `json:"client"`
+	DaysOverdue int             `json:"days_overdue"`
+	AmountDue   fakturoid.Money `json:"amount_due"`
+	To          string          `json:"to,omitempty"`
+	Email       reminder.Email  `json:"email"`
+	Warnings    []string        `json:"warnings,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Sent        bool            `json:"sent"`
+
+	qr string
+}
+
+type remindResult struct {
+	AsOf         string            `json:"as_of"`
+	Preview      bool              `json:"preview"`
+	Reminders    []invoiceReminder `json:"reminders"`
+	Confirmation string            `json:"confirmation,omitempty"`
+}
+
+func invoiceRemindHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		invoiceID := intParam(req, "invoice_id", 0)
+		email := req.GetString("email", "")
+		if email != "" && invoiceID == 0 {
+			return mcp.NewToolResultError("email can only be given with invoice_id"), nil
+		}
+		language := req.GetString("language", "auto")
+		if language != "auto" {
+			if _, ok := r.reminders[language]; !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("no reminder templates for language %q", language)), nil
+			}
+		}
+		level := intParam(req, "level", 0)
+		minDays := intParam(req, "min_days", 1)
+		send := req.GetBool("send", false)
+		confirm := req.GetString("confirm", "")
+		emailCopy := req.GetString("email_copy", "")
+		asOf := time.Now()
+
+		var invoices []fakturoid.Invoice
+		if invoiceID != 0 {
+			inv, err := r.client.GetInvoice(invoiceID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get invoice: %v", err)), nil
+			}
+			invoices = []fakturoid.Invoice{*inv}
+		} else {
+			all, err := unpaidInvoices(r, intParam(req, "subject_id", 0), false)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+			}
+			for _, inv := range all {
+				if report.DaysOverdue(inv.DueOn, asOf) >= minDays {
+					invoices = append(invoices, inv)
+				}
+			}
+		}
+		if raw, ok := req.GetArguments()["invoice_ids"]; ok {
+			// A list that yields no IDs must not widen the selection to every overdue invoice.
+			only := req.GetIntSlice("invoice_ids", nil)
+			if items, isList := raw.([]any); len(only) == 0 || (isList && len(items) != len(only)) {
+				return mcp.NewToolResultError("invoice_ids must be a non-empty list of invoice IDs"), nil
+			}
+			invoices = filter(invoices, func(inv fakturoid.Invoice) bool {
+				return slices.Contains(only, inv.ID)
+			})
+		}
+		if len(invoices) == 0 {
+			return mcp.NewToolResultText("No overdue invoices to remind"), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+
+		result := remindResult{AsOf: asOf.Format("2006-01-02"), Preview: true}
+		emails := map[int]string{}
+		for _, inv := range invoices {
+			rem := draftReminder(r, inv, asOf, language, level, account)
+			switch {
+			case rem.Error != "":
+			case email != "":
+				rem.To = email
+			default:
+				to, ok := emails[inv.SubjectID]
+				if !ok {
+					subject, err := r.client.GetSubject(inv.SubjectID)
+					if err != nil {
+						rem.Error = fmt.Sprintf("get subject: %v", err)
+						break
+					}
+					to = subject.Email
+					emails[inv.SubjectID] = to
+				}
+				rem.To = to
+				if to == "" {
+					rem.Error = "the subject has no email; pass email"
+				}
+			}
+			result.Reminders = append(result.Reminders, rem)
+		}
+
+		code := reminderCode(result.Reminders, emailCopy)
+		if send && confirm != "" {
+			if confirm != code {
+				return mcp.NewToolResultError("The reminders changed since the preview (or the confirmation code is wrong). Nothing was sent; preview again."), nil
+			}
+			result.Preview = false
+			for i := range result.Reminders {
+				rem := &result.Reminders[i]
+				if rem.Error != "" {
+					continue
+				}
+				err := r.client.SendInvoice(rem.InvoiceID, fakturoid.SendInvoiceRequest{
+					Email:     rem.To,
+					EmailCopy: emailCopy,
+					Subject:   rem.Email.Subject,
+					Message:   rem.Email.Body,
+				})
+				if err != nil {
+					rem.Error = fmt.Sprintf("send: %v", err)
+				} else {
+					rem.Sent = true
+				}
+			}
+			return tableAndJSONResult(remindTable(result), result), nil
+		}
+		result.Confirmation = code
+
+		out := tableAndJSONResult(remindTable(result), result)
+		shown := 0
+		for _, rem := range result.Reminders {
+			if rem.qr == "" || shown == maxReminderQRCodes {
+				continue
+			}
+			png, _, err := renderQR(rem.qr, "png", 256)
+			if err != nil {
+				continue
+			}
+			out.Content = append(out.Content,
+				mcp.NewTextContent(fmt.Sprintf("Payment QR code for invoice %s:", rem.Number)),
+				mcp.NewImageContent(base64.StdEncoding.EncodeToString(png), "image/png"),
+			)
+			shown++
+		}
+		return out, nil
+	}
+}
+
+// draftReminder renders the reminder email of one invoice.
+func draftReminder(r *registry, inv fakturoid.Invoice, asOf time.Time, language string, level int, account *fakturoid.Account) invoiceReminder {
+	remaining := inv.RemainingAmount
+	if remaining.IsZero() {
+		remaining = inv.Total
+	}
+	rem := invoiceReminder{
+		InvoiceID:   inv.ID,
+		Number:      inv.Number,
+		Client:      report.SubjectName(inv),
+		DaysOverdue: report.DaysOverdue(inv.DueOn, asOf),
+		AmountDue:   fakturoid.NewMoney(remaining, inv.Currency),
+	}
+	if inv.Status == "paid" || inv.Status == "cancelled" {
+		rem.Error = fmt.Sprintf("invoice is %s", inv.Status)
+		return rem
+	}
+	if rem.DaysOverdue < 1 {
+		rem.Warnings = append(rem.Warnings, "invoice is not overdue yet")
+	}
+
+	in := reminder.Invoice{
+		Invoice:     inv,
+		DaysOverdue: rem.DaysOverdue,
+		Amount:      rem.AmountDue,
+		BankAccount: inv.BankAccount,
+		IBAN:        inv.IBAN,
+		Sender:      account.Name,
+	}
+	if payment, err := invoicePayment(r, &inv, ""); err != nil {
+		rem.Warnings = append(rem.Warnings, fmt.Sprintf("no payment details: %v", err))
+	} else {
+		in.IBAN = payment.IBAN
+		if payload, _, err := paymentQRPayload(payment, "auto"); err == nil {
+			rem.qr = payload
+		}
+	}
+
+	if language == "auto" {
+		language = reminder.Language(inv)
+	}
+	email, err := r.reminders.Render(language, level, in)
+	if err != nil {
+		rem.Error = err.Error()
+		return rem
+	}
+	rem.Email = email
+	switch {
+	case inv.PublicHTMLURL == "":
+		rem.Warnings = append(rem.Warnings, "the invoice has no public page, so the email carries no payment QR code")
+	case !strings.Contains(email.Body, inv.PublicHTMLURL):
+		rem.Warnings = append(rem.Warnings, "the template leaves out the invoice link, so the email carries no payment QR code")
+	}
+	return rem
+}
+
+// reminderCode identifies the reminders of a preview, so a confirmed call sends exactly
+// what was previewed.
+func reminderCode(reminders []invoiceReminder, emailCopy string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "remind|%s", emailCopy)
+	for _, rem := range reminders {
+		if rem.Error == "" {
+			fmt.Fprintf(h, "|%d:%s:%s:%s", rem.InvoiceID, rem.To, rem.Email.Subject, rem.Email.Body)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:10]
+}
+
+func remindTable(res remindResult) string {
+	var b strings.Builder
+	verb := "Reminder preview"
+	if !res.Preview {
+		verb = "Reminders"
+	}
+	fmt.Fprintf(&b, "%s as of %s\n\n", verb, res.AsOf)
+	b.WriteString("| Invoice | Client | Days overdue | Amount due | Level | To | Status |\n|---|---|---:|---:|---:|---|---|\n")
+	for _, rem := range res.Reminders {
+		status := "draft"
+		switch {
+		case rem.Error != "":
+			status = "error: " + rem.Error
+		case rem.Sent:
+			status = "sent"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %d | %s | %d | %s | %s |\n", rem.Number, rem.Client, rem.DaysOverdue, rem.AmountDue, rem.Email.Level, rem.To, status)
+	}
+	if res.Preview {
+		for _, rem := range res.Reminders {
+			if rem.Error != "" {
+				continue
+			}
+			fmt.Fprintf(&b, "\n---\nTo: %s\nSubject: %s\n\n%s\n", rem.To, rem.Email.Subject, rem.Email.Body)
+			for _, w := range rem.Warnings {
+				fmt.Fprintf(&b, "\nWarning: %s\n", w)
+			}
+		}
+		fmt.Fprintf(&b, "\nNothing was sent. To send, call again with the same selection, send=true and confirm=%q.\n", res.Confirmation)
+	}
+	return b.String()
+}
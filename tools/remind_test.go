@@ -0,0 +1,118 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/reminder"
+)
+
+func TestInvoiceRemindConfirmation(t *testing.T) {
+	var sent, messages []string
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /invoices/7.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, fakturoid.Invoice{
+				ID: 7, Number: "2026-0007", SubjectID: 3, Status: "overdue", Currency: "CZK", Language: "cs",
+				DueOn: "2026-01-10", Total: fakturoid.NewAmount(1210), VariableSymbol: "20260007",
+				IBAN: "CZ6508000000192000145399", YourName: "Test s.r.o.", PublicHTMLURL: "https://app.fakturoid.cz/test/p/abc",
+			})
+		},
+		"GET /account.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"name": "Test s.r.o.", "currency": "CZK"})
+		},
+		"GET /subjects/3.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"id": 3, "name": "Alfa s.r.o.", "email": "ucetni@alfa.cz"})
+		},
+		"POST /invoices/7/message.json": func(w http.ResponseWriter, req *http.Request) {
+			var body fakturoid.SendInvoiceRequest
+			_ = json.NewDecoder(req.Body).Decode(&body)
+			sent = append(sent, body.Email)
+			messages = append(messages, body.Message)
+			w.WriteHeader(http.StatusCreated)
+		},
+	})
+	r.reminders = reminder.Defaults
+	h := invoiceRemindHandler(r)
+
+	preview := callTool(t, h, map[string]any{"invoice_id": 7, "send": true})
+	res := preview.StructuredContent.(remindResult)
+	if preview.IsError || !res.Preview || res.Confirmation == "" || len(sent) > 0 {
+		t.Fatalf("send without confirm: preview %v, code %q, sent %q\n%s", res.Preview, res.Confirmation, sent, resultText(preview))
+	}
+	if !strings.Contains(resultText(preview), "confirm=\""+res.Confirmation+"\"") {
+		t.Errorf("preview does not show the confirmation code:\n%s", resultText(preview))
+	}
+
+	if wrong := callTool(t, h, map[string]any{"invoice_id": 7, "send": true, "confirm": "0000000000"}); !wrong.IsError || len(sent) > 0 {
+		t.Fatalf("wrong code: error %v, sent %q", wrong.IsError, sent)
+	}
+	if other := callTool(t, h, map[string]any{"invoice_id": 7, "send": true, "confirm": res.Confirmation, "email": "jiny@alfa.cz"}); !other.IsError || len(sent) > 0 {
+		t.Fatalf("changed recipient: error %v, sent %q", other.IsError, sent)
+	}
+
+	done := callTool(t, h, map[string]any{"invoice_id": 7, "send": true, "confirm": res.Confirmation})
+	res = done.StructuredContent.(remindResult)
+	if done.IsError || res.Preview || len(res.Reminders) != 1 || !res.Reminders[0].Sent {
+		t.Fatalf("confirmed send: %s", resultText(done))
+	}
+	if len(sent) != 1 || sent[0] != "ucetni@alfa.cz" {
+		t.Errorf("sent to %q, want ucetni@alfa.cz", sent)
+	}
+	if !strings.Contains(messages[0], "https://app.fakturoid.cz/test/p/abc") {
+		t.Errorf("sent reminder does not link the payment QR code:\n%s", messages[0])
+	}
+	if w := res.Reminders[0].Warnings; len(w) > 0 {
+		t.Errorf("warnings = %q", w)
+	}
+}
+
+func TestInvoiceRemindInvoiceIDs(t *testing.T) {
+	overdue := func(id int) fakturoid.Invoice {
+		return fakturoid.Invoice{ID: id, Number: fmt.Sprintf("2026-%04d", id), SubjectID: 3, Status: "overdue", Currency: "CZK",
+			DueOn: "2026-01-10", Total: fakturoid.NewAmount(1210), IBAN: "CZ6508000000192000145399", YourName: "Test s.r.o."}
+	}
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /invoices.json": func(w http.ResponseWriter, req *http.Request) {
+			if req.URL.Query().Get("status") != "overdue" {
+				writeJSON(w, []any{})
+				return
+			}
+			writeJSON(w, []fakturoid.Invoice{overdue(7), overdue(8), overdue(9)})
+		},
+		"GET /account.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"name": "Test s.r.o.", "currency": "CZK"})
+		},
+		"GET /subjects/3.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"id": 3, "name": "Alfa s.r.o.", "email": "ucetni@alfa.cz"})
+		},
+	})
+	r.reminders = reminder.Defaults
+	h := invoiceRemindHandler(r)
+
+	// JSON numbers arrive as float64.
+	for _, ids := range []any{[]any{float64(7), float64(9)}, []any{"7", "9"}} {
+		res := callTool(t, h, map[string]any{"invoice_ids": ids})
+		if res.IsError {
+			t.Fatalf("%v: %s", ids, resultText(res))
+		}
+		rems := res.StructuredContent.(remindResult).Reminders
+		if len(rems) != 2 || rems[0].InvoiceID != 7 || rems[1].InvoiceID != 9 {
+			t.Errorf("%v: reminders for %+v, want 7 and 9", ids, rems)
+		}
+	}
+	for _, ids := range []any{[]any{}, []any{"abc"}, []any{float64(7), "abc"}} {
+		if res := callTool(t, h, map[string]any{"invoice_ids": ids}); !res.IsError {
+			t.Errorf("%v accepted: %s", ids, resultText(res))
+		}
+	}
+
+	// Without a public page the reminder cannot carry the QR code.
+	rems := callTool(t, h, map[string]any{"invoice_ids": []any{float64(8)}}).StructuredContent.(remindResult).Reminders
+	if len(rems) != 1 || len(rems[0].Warnings) != 1 || !strings.Contains(rems[0].Warnings[0], "no payment QR code") {
+		t.Errorf("reminder without a link: %+v", rems)
+	}
+}
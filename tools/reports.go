@@ -0,0 +1,265 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/report"
+)
+
+// unpaidStatuses are the invoice statuses that still expect a payment.
+var unpaidStatuses = []string{"open", "sent", "overdue"}
+
+func registerReportTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_report_aging",
+			mcp.WithDescription("Receivables aging: unpaid invoices grouped by subject and days past due (current, 1–30, 31–60, 61–90, 90+), in account currency. Returns a table and structured JSON."),
+			mcp.WithString("as_of", mcp.Description("Reference date (YYYY-MM-DD, default today)")),
+			mcp.WithNumber("subject_id", mcp.Description("Only invoices of this subject")),
+			mcp.WithBoolean("include_proforma", mcp.Description("Include proforma invoices (default false)")),
+		),
+		reportAgingHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_report_summary",
+			mcp.WithDescription("Revenue and expense summary for a date range in account currency, broken down by month or quarter, subject, tag, currency and VAT rate. Returns a table and structured JSON."),
+			mcp.WithString("from", mcp.Required(), mcp.Description("Start date (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("to", mcp.Required(), mcp.Description("End date (YYYY-MM-DD, inclusive)")),
+			mcp.WithString("basis", mcp.Description("issued (by issue date, default) or paid (by payment date)")),
+			mcp.WithString("period", mcp.Description("month (default) or quarter")),
+		),
+		reportSummaryHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_report_vat_cz",
+			mcp.WithDescription("Czech VAT return (přiznání k DPH) and control statement (kontrolní hlášení) data for a month or quarter: output VAT from invoices by taxable fulfillment date, input VAT from expenses, split by rate and sections A.4/A.5/B.2/B.3. Optionally renders the control statement as EPO XML for review; nothing is submitted."),
+			mcp.WithNumber("year", mcp.Required(), mcp.Description("Year of the period")),
+			mcp.WithNumber("month", mcp.Description("Month (1–12) for monthly filers")),
+			mcp.WithNumber("quarter", mcp.Description("Quarter (1–4) for quarterly filers")),
+			mcp.WithBoolean("export_xml", mcp.Description("Attach the control statement as EPO XML (DPHKH1)")),
+			mcp.WithString("tax_office", mcp.Description("Tax office code (c_ufo) for the XML")),
+			mcp.WithString("workplace", mcp.Description("Territorial workplace code (c_pracufo) for the XML")),
+			mcp.WithBoolean("individual", mcp.Description("The filer is a natural person (typ_ds F, name split into first and last name); default legal person")),
+		),
+		reportVATCZHandler(r),
+	)
+}
+
+func reportAgingHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		asOf := time.Now()
+		if v := req.GetString("as_of", ""); v != "" {
+			t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid as_of: %v", err)), nil
+			}
+			asOf = t
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+
+		invoices, err := unpaidInvoices(r, intParam(req, "subject_id", 0), req.GetBool("include_proforma", false))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+		}
+
+		// Open foreign-currency amounts without a rate are valued at the rate of the report day.
+		asOfDate := asOf.Format("2006-01-02")
+		warnings := fillInvoiceRates(ctx, r, invoices, account.Currency, func(fakturoid.Invoice) string { return asOfDate })
+
+		rep := report.Aging(invoices, account.Currency, asOf)
+		return withWarnings(tableAndJSONResult(rep.Table(), rep), warnings), nil
+	}
+}
+
+// unpaidInvoices pages through all invoices still waiting for payment.
+func unpaidInvoices(r *registry, subjectID int, includeProforma bool) ([]fakturoid.Invoice, error) {
+	var result []fakturoid.Invoice
+	for _, status := range unpaidStatuses {
+		params := url.Values{}
+		params.Set("status", status)
+		if subjectID != 0 {
+			params.Set("subject_id", fmt.Sprintf("%d", subjectID))
+		}
+		invoices, err := fakturoid.AllPages(func(page int) ([]fakturoid.Invoice, error) {
+			return r.client.GetInvoices(page, params)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, inv := range invoices {
+			if !includeProforma && report.IsProforma(inv) {
+				continue
+			}
+			result = append(result, inv)
+		}
+	}
+	return result, nil
+}
+
+// withWarnings appends warnings to a result as a text block.
+func withWarnings(result *mcp.CallToolResult, warnings []string) *mcp.CallToolResult {
+	if len(warnings) > 0 {
+		result.Content = append(result.Content, mcp.NewTextContent("Warnings:\n- "+strings.Join(warnings, "\n- ")))
+	}
+	return result
+}
+
+// tableAndJSONResult returns a human-readable table and the same data as structured JSON.
+func tableAndJSONResult(table string, data any) *mcp.CallToolResult {
+	result := mcp.NewToolResultStructured(data, table)
+	result.Content = append(result.Content, mcp.NewTextContent(toJSON(data)))
+	return result
+}
+
+func reportSummaryHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		from, err := time.ParseInLocation("2006-01-02", req.GetString("from", ""), time.Local)
+		if err != nil {
+			return mcp.NewToolResultError("from is required (YYYY-MM-DD)"), nil
+		}
+		to, err := time.ParseInLocation("2006-01-02", req.GetString("to", ""), time.Local)
+		if err != nil {
+			return mcp.NewToolResultError("to is required (YYYY-MM-DD)"), nil
+		}
+		if to.Before(from) {
+			return mcp.NewToolResultError("to must not be before from"), nil
+		}
+		basis := req.GetString("basis", report.BasisIssued)
+		if basis != report.BasisIssued && basis != report.BasisPaid {
+			return mcp.NewToolResultError("basis must be issued or paid"), nil
+		}
+		period := req.GetString("period", report.PeriodMonth)
+		if period != report.PeriodMonth && period != report.PeriodQuarter {
+			return mcp.NewToolResultError("period must be month or quarter"), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		params := reportParams(from, basis)
+		invoices, err := fakturoid.AllPages(func(page int) ([]fakturoid.Invoice, error) {
+			return r.client.GetInvoices(page, params)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+		}
+		expenses, err := fakturoid.AllPages(func(page int) ([]fakturoid.Expense, error) {
+			return r.client.GetExpenses(page, params)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list expenses: %v", err)), nil
+		}
+
+		opts := report.SummaryOptions{
+			From:            from,
+			To:              to,
+			Basis:           basis,
+			Period:          period,
+			AccountCurrency: account.Currency,
+		}
+		invoices, expenses = opts.Invoices(invoices), opts.Expenses(expenses)
+		warnings := fillInvoiceRates(ctx, r, invoices, account.Currency, invoiceRateDate)
+		warnings = append(warnings, fillExpenseRates(ctx, r, expenses, account.Currency, expenseRateDate)...)
+
+		rep := report.Summary(invoices, expenses, opts)
+		return withWarnings(tableAndJSONResult(rep.Table(), rep), warnings), nil
+	}
+}
+
+// reportLookback is how many months before a report's first day its documents may have
+// been created. Fakturoid filters lists by creation time, not issue date; documents are
+// created on or after their issue date unless they were prepared in advance.
+const reportLookback = 3
+
+// reportParams limits invoice and expense lists to the documents that can fall into a
+// report starting at from. A document paid in the period was updated by the payment, so
+// on the paid basis the update time bounds it exactly.
+func reportParams(from time.Time, basis string) url.Values {
+	params := url.Values{}
+	if basis == report.BasisPaid {
+		params.Set("updated_since", from.Format(time.RFC3339))
+	} else {
+		params.Set("since", from.AddDate(0, -reportLookback, 0).Format(time.RFC3339))
+	}
+	return params
+}
+
+func reportVATCZHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		period := report.VATPeriod{
+			Year:    intParam(req, "year", 0),
+			Month:   intParam(req, "month", 0),
+			Quarter: intParam(req, "quarter", 0),
+		}
+		if err := period.Validate(); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		account, err := r.client.GetAccount()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get account: %v", err)), nil
+		}
+		if !strings.EqualFold(account.Currency, "CZK") || !account.VATPayer() {
+			return mcp.NewToolResultError("Czech VAT reports require a CZK account of a VAT payer"), nil
+		}
+
+		from, _ := period.Bounds()
+		params := reportParams(from, report.BasisIssued)
+		invoices, err := fakturoid.AllPages(func(page int) ([]fakturoid.Invoice, error) {
+			return r.client.GetInvoices(page, params)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list invoices: %v", err)), nil
+		}
+		expenses, err := fakturoid.AllPages(func(page int) ([]fakturoid.Expense, error) {
+			return r.client.GetExpenses(page, params)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list expenses: %v", err)), nil
+		}
+
+		invoices, expenses = period.Invoices(invoices), period.Expenses(expenses)
+		warnings := fillInvoiceRates(ctx, r, invoices, account.Currency, invoiceRateDate)
+		warnings = append(warnings, fillExpenseRates(ctx, r, expenses, account.Currency, expenseRateDate)...)
+
+		rep := report.CzechVAT(invoices, expenses, period)
+		result := withWarnings(tableAndJSONResult(rep.Table(), rep), warnings)
+		if !req.GetBool("export_xml", false) {
+			return result, nil
+		}
+
+		data, err := rep.ControlStatementXML(report.Taxpayer{
+			VATNo:      account.VATNo,
+			Name:       account.Name,
+			Individual: req.GetBool("individual", false),
+			Street:     account.Street,
+			City:       account.City,
+			Zip:        account.Zip,
+			Email:      account.Email,
+			TaxOffice:  req.GetString("tax_office", ""),
+			Workplace:  req.GetString("workplace", ""),
+		}, time.Now())
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to build control statement XML: %v", err)), nil
+		}
+		result.Content = append(result.Content, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+			URI:      fmt.Sprintf("fakturoid://reports/kh-%s.xml", rep.Period),
+			MIMEType: "text/xml",
+			Text:     string(data),
+		}))
+		return result, nil
+	}
+}
@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/exrate"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/report"
+)
+
+// reportTestRegistry serves a CZK VAT payer with an EUR invoice in March 2026 and a USD
+// invoice from January, for which the fixed rates have no rate.
+func reportTestRegistry(t *testing.T) *registry {
+	line := func(price string) []fakturoid.InvoiceLine {
+		return []fakturoid.InvoiceLine{{Name: "Služby", Quantity: fakturoid.NewAmount(1), UnitPrice: fakturoid.MustParseAmount(price), VATRate: fakturoid.NewAmount(21)}}
+	}
+	invoices := []fakturoid.Invoice{
+		{ID: 1, Number: "2026-0001", Currency: "USD", IssuedOn: "2026-01-15", TaxableFulfillmentDue: "2026-01-15", PaidOn: "2026-01-20",
+			Total: fakturoid.MustParseAmount("121"), VATPriceMode: fakturoid.VATPriceModeWithVAT, Lines: line("121")},
+		{ID: 2, Number: "2026-0002", Currency: "EUR", IssuedOn: "2026-03-10", TaxableFulfillmentDue: "2026-03-10", PaidOn: "2026-03-20",
+			Total: fakturoid.MustParseAmount("121"), VATPriceMode: fakturoid.VATPriceModeWithVAT, Lines: line("121")},
+	}
+	r := newTestRegistry(t, map[string]http.HandlerFunc{
+		"GET /account.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, map[string]any{"name": "Test s.r.o.", "currency": "CZK", "vat_mode": "vat_payer", "vat_no": "CZ27074358"})
+		},
+		"GET /invoices.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, invoices)
+		},
+		"GET /expenses.json": func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, []any{})
+		},
+	})
+	r.rates = exrate.Fixed{"EUR": fakturoid.MustParseAmount("25")}
+	return r
+}
+
+func TestReportSummaryRatesOnlyForPeriod(t *testing.T) {
+	r := reportTestRegistry(t)
+	for _, basis := range []string{report.BasisIssued, report.BasisPaid} {
+		res := callTool(t, reportSummaryHandler(r), map[string]any{"from": "2026-03-01", "to": "2026-03-31", "basis": basis})
+		if res.IsError || strings.Contains(resultText(res), "Warnings") {
+			t.Fatalf("basis %s: %s", basis, resultText(res))
+		}
+		rep := res.StructuredContent.(report.SummaryReport)
+		if got := rep.Totals.RevenueGross.String(); got != "3025" || rep.Totals.Invoices != 1 {
+			t.Errorf("basis %s: revenue %s from %d invoices, want 3025 from 1", basis, got, rep.Totals.Invoices)
+		}
+	}
+}
+
+func TestReportVATCZRatesOnlyForPeriod(t *testing.T) {
+	r := reportTestRegistry(t)
+	res := callTool(t, reportVATCZHandler(r), map[string]any{"year": 2026, "month": 3})
+	if res.IsError || strings.Contains(resultText(res), "Warnings") {
+		t.Fatalf("%s", resultText(res))
+	}
+	if got := res.StructuredContent.(report.VATReturn).OutputVAT.String(); got != "525" {
+		t.Errorf("output VAT = %s, want 525", got)
+	}
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	accountURI       = "fakturoid://account"
+	subjectURIPrefix = "fakturoid://subjects/"
+	invoiceURIPrefix = "fakturoid://invoices/"
+)
+
+func registerResources(s *server.MCPServer, r *registry) {
+	s.AddResource(
+		mcp.NewResource(accountURI, "Fakturoid account",
+			mcp.WithResourceDescription("Account details: company, address, currency, VAT mode and defaults"),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			account, err := r.client.GetAccount()
+			if err != nil {
+				return nil, fmt.Errorf("get account: %w", err)
+			}
+			return jsonResource(req.Params.URI, account), nil
+		},
+	)
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(subjectURIPrefix+"{id}", "Subject",
+			mcp.WithTemplateDescription("A subject (contact) by ID"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			id, err := resourceID(req.Params.URI, subjectURIPrefix, "")
+			if err != nil {
+				return nil, err
+			}
+			subject, err := r.client.GetSubject(id)
+			if err != nil {
+				return nil, fmt.Errorf("get subject: %w", err)
+			}
+			return jsonResource(req.Params.URI, subject), nil
+		},
+	)
+
+	// The PDF template is more specific, but templates are matched in no particular
+	// order, so both handlers look at the URI themselves.
+	readInvoice := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if id, err := resourceID(req.Params.URI, invoiceURIPrefix, "/pdf"); err == nil {
+			pdf, err := r.client.DownloadInvoicePDF(id)
+			if err != nil {
+				return nil, fmt.Errorf("download invoice PDF: %w", err)
+			}
+			return []mcp.ResourceContents{
+				mcp.BlobResourceContents{
+					URI:      req.Params.URI,
+					MIMEType: "application/pdf",
+					Blob:     base64.StdEncoding.EncodeToString(pdf),
+				},
+			}, nil
+		}
+		id, err := resourceID(req.Params.URI, invoiceURIPrefix, "")
+		if err != nil {
+			return nil, err
+		}
+		invoice, err := r.client.GetInvoice(id)
+		if err != nil {
+			return nil, fmt.Errorf("get invoice: %w", err)
+		}
+		return jsonResource(req.Params.URI, invoice), nil
+	}
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(invoiceURIPrefix+"{id}", "Invoice",
+			mcp.WithTemplateDescription("An invoice by ID, with lines, amounts and status"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		readInvoice,
+	)
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(invoiceURIPrefix+"{id}/pdf", "Invoice PDF",
+			mcp.WithTemplateDescription("The PDF of an invoice by ID"),
+			mcp.WithTemplateMIMEType("application/pdf"),
+		),
+		readInvoice,
+	)
+}
+
+func jsonResource(uri string, v any) []mcp.ResourceContents {
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: uri, MIMEType: "application/json", Text: toJSON(v)},
+	}
+}
+
+// resourceID extracts the numeric ID from prefix{id}suffix.
+func resourceID(uri, prefix, suffix string) (int, error) {
+	rest, ok := strings.CutPrefix(uri, prefix)
+	if !ok {
+		return 0, fmt.Errorf("unknown resource %s", uri)
+	}
+	if suffix != "" {
+		if rest, ok = strings.CutSuffix(rest, suffix); !ok {
+			return 0, fmt.Errorf("unknown resource %s", uri)
+		}
+	}
+	id, err := strconv.Atoi(rest)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid ID in resource %s", uri)
+	}
+	return id, nil
+}
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -188,3 +189,27 @@ func subjectDeleteHandler(r *registry) server.ToolHandlerFunc {
 		return mcp.NewToolResultText(fmt.Sprintf("Subject %d deleted", id)), nil
 	}
 }
+
+// findSubject looks up a subject by registration number (IČO) or VAT number (DIČ).
+// It returns nil when no subject matches exactly.
+func findSubject(r *registry, registrationNo, vatNo string) (*fakturoid.Subject, error) {
+	normalize := func(s string) string { return strings.ToUpper(strings.ReplaceAll(s, " ", "")) }
+	registrationNo, vatNo = normalize(registrationNo), normalize(vatNo)
+
+	for _, query := range []string{registrationNo, vatNo} {
+		if query == "" {
+			continue
+		}
+		subjects, err := r.client.SearchSubjects(query, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subjects {
+			if (registrationNo != "" && normalize(s.RegistrationNo) == registrationNo) ||
+				(vatNo != "" && normalize(s.VATNo) == vatNo) {
+				return &s, nil
+			}
+		}
+	}
+	return nil, nil
+}
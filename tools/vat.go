@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/vatcheck"
+)
+
+func registerVATTools(s *server.MCPServer, r *registry) {
+	if len(r.vat) == 0 {
+		return
+	}
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_vat_check",
+			mcp.WithDescription("Validate a VAT number in VIES and, for Czech numbers, the register of unreliable VAT payers. "+
+				"With bank_account, also check that the account is published for the payer."),
+			mcp.WithString("vat_no", mcp.Description("VAT number with country prefix, e.g. CZ12345678 (default: the subject's)")),
+			mcp.WithNumber("subject_id", mcp.Description("Subject whose VAT number to check")),
+			mcp.WithString("bank_account", mcp.Description("Bank account to check, e.g. 19-2000145399/0800 or an IBAN")),
+		),
+		vatCheckHandler(r),
+	)
+}
+
+func vatCheckHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		vatNo := req.GetString("vat_no", "")
+		if id := intParam(req, "subject_id", 0); id != 0 && vatNo == "" {
+			subject, err := r.client.GetSubject(id)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get subject: %v", err)), nil
+			}
+			if subject.VATNo == "" {
+				return mcp.NewToolResultError(fmt.Sprintf("Subject %d has no VAT number", id)), nil
+			}
+			vatNo = subject.VATNo
+		}
+		if vatNo == "" {
+			return mcp.NewToolResultError("vat_no or subject_id is required"), nil
+		}
+		if _, _, err := vatcheck.Split(vatNo); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		report := vatcheck.Run(ctx, r.vat, vatNo, req.GetString("bank_account", ""))
+		return mcp.NewToolResultStructured(report, toJSON(report)), nil
+	}
+}
+
+// vatPreflight checks a counterparty before an invoice or a payment is made. It fails
+// when a register reports a problem, and when no register could confirm the number
+// unless allowUnverified is set; then the unanswered registers become warnings.
+func vatPreflight(ctx context.Context, r *registry, vatNo, bankAccount string, allowUnverified bool) ([]string, error) {
+	if len(r.vat) == 0 {
+		return nil, fmt.Errorf("VAT checks are not configured")
+	}
+	if vatNo == "" {
+		return []string{"VAT check skipped: the counterparty has no VAT number"}, nil
+	}
+
+	report := vatcheck.Run(ctx, r.vat, vatNo, bankAccount)
+	if !report.OK() {
+		return nil, fmt.Errorf("VAT check failed: %s", strings.Join(report.Problems, "; "))
+	}
+	if !report.Verified && !allowUnverified {
+		return nil, fmt.Errorf("VAT check could not verify %s: %s; pass allow_unverified=true to continue anyway",
+			report.VATNo, strings.Join(report.Errors, "; "))
+	}
+	var warnings []string
+	for _, e := range report.Errors {
+		warnings = append(warnings, "VAT check incomplete: "+e)
+	}
+	return warnings, nil
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tedyno/fakturoid-mcp/vatcheck"
+)
+
+type failingChecker struct{}
+
+func (failingChecker) Check(ctx context.Context, vatNo string) (*vatcheck.Result, error) {
+	return nil, errors.New("VIES: service unavailable")
+}
+
+func TestVATPreflight(t *testing.T) {
+	valid, invalid := true, false
+	static := vatcheck.Static{
+		"CZ27074358": {Source: "static", VATNo: "CZ27074358", Valid: &valid},
+		"CZ12345678": {Source: "static", VATNo: "CZ12345678", Valid: &invalid},
+	}
+
+	tests := []struct {
+		name            string
+		checkers        []vatcheck.Checker
+		vatNo           string
+		allowUnverified bool
+		wantErr         string
+		wantWarnings    int
+	}{
+		{"verified", []vatcheck.Checker{static}, "CZ 27074358", false, "", 0},
+		{"invalid", []vatcheck.Checker{static}, "CZ12345678", true, "not a valid VAT number", 0},
+		{"no register covers", []vatcheck.Checker{static}, "DE123456789", false, "could not verify DE123456789", 0},
+		{"no register covers, allowed", []vatcheck.Checker{static}, "DE123456789", true, "", 1},
+		{"register down", []vatcheck.Checker{static, failingChecker{}}, "CZ27074358", false, "service unavailable", 0},
+		{"register down, allowed", []vatcheck.Checker{static, failingChecker{}}, "CZ27074358", true, "", 1},
+		{"no VAT number", []vatcheck.Checker{static}, "", false, "", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &registry{vat: tt.checkers}
+			warnings, err := vatPreflight(context.Background(), r, tt.vatNo, "", tt.allowUnverified)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %q, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
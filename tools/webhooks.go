@@ -0,0 +1,184 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/tedyno/fakturoid-mcp/fakturoid"
+	"github.com/tedyno/fakturoid-mcp/webhook"
+)
+
+const webhookEventsURI = "fakturoid://webhook-events"
+
+func registerWebhookTools(s *server.MCPServer, r *registry) {
+	s.AddTool(
+		mcp.NewTool("fakturoid_webhook_list",
+			mcp.WithDescription("List webhooks configured in Fakturoid"),
+		),
+		webhookListHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_webhook_create",
+			mcp.WithDescription("Create a webhook. Fakturoid sends auth_header as the Authorization header of each delivery."),
+			mcp.WithString("webhook_url", mcp.Required(), mcp.Description("HTTPS URL receiving the deliveries")),
+			mcp.WithArray("events", mcp.Required(), mcp.WithStringItems(), mcp.Description("Event names, e.g. invoice_paid, invoice_overdue, expense_paid")),
+			mcp.WithString("auth_header", mcp.Description("Secret sent in the Authorization header (must match the receiver's webhook_secret)")),
+			mcp.WithBoolean("active", mcp.Description("Whether the webhook is active (default true)")),
+		),
+		webhookCreateHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_webhook_update",
+			mcp.WithDescription("Update a webhook"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Webhook ID")),
+			mcp.WithString("webhook_url", mcp.Description("HTTPS URL receiving the deliveries")),
+			mcp.WithArray("events", mcp.WithStringItems(), mcp.Description("Event names (replaces the current list)")),
+			mcp.WithString("auth_header", mcp.Description("Secret sent in the Authorization header")),
+			mcp.WithBoolean("active", mcp.Description("Whether the webhook is active")),
+		),
+		webhookUpdateHandler(r),
+	)
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_webhook_delete",
+			mcp.WithDescription("Delete a webhook"),
+			mcp.WithNumber("id", mcp.Required(), mcp.Description("Webhook ID")),
+		),
+		webhookDeleteHandler(r),
+	)
+
+	// Received events are only available when the local receiver is running.
+	if r.webhooks == nil {
+		return
+	}
+
+	s.AddTool(
+		mcp.NewTool("fakturoid_webhook_events",
+			mcp.WithDescription("Webhook events received by the local receiver, newest first"),
+			mcp.WithString("event_name", mcp.Description("Filter by event name (e.g. invoice_paid)")),
+			mcp.WithString("since", mcp.Description("Only events received since this time (RFC 3339 or YYYY-MM-DD)")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of events (default 50)")),
+		),
+		webhookEventsHandler(r),
+	)
+
+	s.AddResource(
+		mcp.NewResource(webhookEventsURI, "Webhook events",
+			mcp.WithResourceDescription("Webhook events received by the local receiver, newest first"),
+			mcp.WithMIMEType("application/json"),
+		),
+		func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+			return []mcp.ResourceContents{
+				mcp.TextResourceContents{
+					URI:      webhookEventsURI,
+					MIMEType: "application/json",
+					Text:     toJSON(r.webhooks.List("", time.Time{}, 0)),
+				},
+			}, nil
+		},
+	)
+}
+
+// NotifyWebhookEvent logs an arrived webhook event to connected clients.
+func NotifyWebhookEvent(s *server.MCPServer, e webhook.Event) {
+	s.SendNotificationToAllClients("notifications/message", map[string]any{
+		"level":  "info",
+		"logger": "fakturoid-webhook",
+		"data":   e,
+	})
+}
+
+func webhookListHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		webhooks, err := r.client.GetWebhooks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list webhooks: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(webhooks)), nil
+	}
+}
+
+func webhookCreateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		webhookURL := req.GetString("webhook_url", "")
+		if webhookURL == "" {
+			return mcp.NewToolResultError("webhook_url is required"), nil
+		}
+		events := req.GetStringSlice("events", nil)
+		if len(events) == 0 {
+			return mcp.NewToolResultError("at least one event is required"), nil
+		}
+
+		createReq := fakturoid.CreateWebhookRequest{
+			WebhookURL: webhookURL,
+			AuthHeader: req.GetString("auth_header", ""),
+			Active:     optionalBool(req, "active"),
+			Events:     events,
+		}
+
+		hook, err := r.client.CreateWebhook(createReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create webhook: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(hook)), nil
+	}
+}
+
+func webhookUpdateHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		updateReq := fakturoid.UpdateWebhookRequest{
+			WebhookURL: req.GetString("webhook_url", ""),
+			AuthHeader: req.GetString("auth_header", ""),
+			Active:     optionalBool(req, "active"),
+			Events:     req.GetStringSlice("events", nil),
+		}
+
+		hook, err := r.client.UpdateWebhook(id, updateReq)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to update webhook: %v", err)), nil
+		}
+		return mcp.NewToolResultText(toJSON(hook)), nil
+	}
+}
+
+func webhookDeleteHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		id := intParam(req, "id", 0)
+		if id == 0 {
+			return mcp.NewToolResultError("id is required"), nil
+		}
+
+		err := r.client.DeleteWebhook(id)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to delete webhook: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Webhook %d deleted", id)), nil
+	}
+}
+
+func webhookEventsHandler(r *registry) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var since time.Time
+		if v := req.GetString("since", ""); v != "" {
+			t, err := parseTimeParam(v)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Invalid since: %v", err)), nil
+			}
+			since = t
+		}
+		limit := intParam(req, "limit", 50)
+
+		events := r.webhooks.List(req.GetString("event_name", ""), since, limit)
+		return mcp.NewToolResultText(toJSON(events)), nil
+	}
+}
@@ -0,0 +1,127 @@
+package vatcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultADISURL is the SOAP service of the Czech register of VAT payers.
+const DefaultADISURL = "https://adisrws.mfcr.cz/dpr/axis2/services/rozhraniCRPDPH.rozhraniCRPDPHSOAP"
+
+// ADIS checks Czech VAT payers in the register of unreliable payers kept by the
+// Financial Administration. It also returns the payer's published bank accounts.
+type ADIS struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+func NewADIS() *ADIS {
+	return &ADIS{
+		URL:        DefaultADISURL,
+		HTTPClient: &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+const adisRequest = `<?xml version="1.0" encoding="UTF-8"?>
+<soapenv:Envelope xmlns:soapenv="http://schemas.xmlsoap.org/soap/envelope/">
+  <soapenv:Body>
+    <StatusNespolehlivyPlatceRequest xmlns="http://adis.mfcr.cz/rozhraniCRPDPH/">
+      <dic>%s</dic>
+    </StatusNespolehlivyPlatceRequest>
+  </soapenv:Body>
+</soapenv:Envelope>`
+
+type adisEnvelope struct {
+	Status struct {
+		Code int    `xml:"statusCode,attr"`
+		Text string `xml:"statusText,attr"`
+	} `xml:"Body>StatusNespolehlivyPlatceResponse>status"`
+	Payers []struct {
+		DIC        string `xml:"dic,attr"`
+		Unreliable string `xml:"nespolehlivyPlatce,attr"`
+		Since      string `xml:"datumZverejneniNespolehlivosti,attr"`
+		Accounts   []struct {
+			Standard *struct {
+				Prefix string `xml:"predcisli,attr"`
+				Number string `xml:"cislo,attr"`
+				Bank   string `xml:"kodBanky,attr"`
+			} `xml:"standardniUcet"`
+			Other *struct {
+				Number string `xml:"cislo,attr"`
+			} `xml:"nestandardniUcet"`
+		} `xml:"zverejneneUcty>ucet"`
+	} `xml:"Body>StatusNespolehlivyPlatceResponse>statusPlatceDPH"`
+}
+
+func (a *ADIS) Check(ctx context.Context, vatNo string) (*Result, error) {
+	country, number, err := Split(vatNo)
+	if err != nil {
+		return nil, err
+	}
+	if country != "CZ" {
+		return nil, ErrNotApplicable
+	}
+
+	body := fmt.Sprintf(adisRequest, number)
+	req, err := http.NewRequestWithContext(ctx, "POST", a.URL, bytes.NewBufferString(body))
+	if err != nil {
+		return nil, fmt.Errorf("create ADIS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	req.Header.Set("SOAPAction", "getStatusNespolehlivyPlatce")
+
+	resp, err := a.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("ADIS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read ADIS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ADIS error (%d): %s", resp.StatusCode, string(data))
+	}
+
+	var env adisEnvelope
+	if err := xml.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("parse ADIS response: %w", err)
+	}
+	if env.Status.Code != 0 {
+		return nil, fmt.Errorf("ADIS error (%d): %s", env.Status.Code, env.Status.Text)
+	}
+	if len(env.Payers) == 0 {
+		return nil, fmt.Errorf("ADIS returned no status for %s", vatNo)
+	}
+
+	p := env.Payers[0]
+	res := &Result{Source: "ADIS", VATNo: "CZ" + number}
+	valid := p.Unreliable != "NENALEZEN"
+	res.Valid = &valid
+	if valid {
+		unreliable := p.Unreliable == "ANO"
+		res.Unreliable = &unreliable
+		if unreliable {
+			res.UnreliableSince = p.Since
+		}
+		res.BankAccounts = []string{}
+		for _, acc := range p.Accounts {
+			switch {
+			case acc.Standard != nil:
+				number := acc.Standard.Number + "/" + acc.Standard.Bank
+				if acc.Standard.Prefix != "" {
+					number = acc.Standard.Prefix + "-" + number
+				}
+				res.BankAccounts = append(res.BankAccounts, number)
+			case acc.Other != nil:
+				res.BankAccounts = append(res.BankAccounts, acc.Other.Number)
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,148 @@
+// Package vatcheck validates VAT numbers against public registers: VIES for EU VAT
+// identification numbers and the Czech ADIS register of unreliable VAT payers.
+package vatcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotApplicable is returned by a checker that does not cover the VAT number's country.
+var ErrNotApplicable = errors.New("VAT number not covered by this register")
+
+// Result is the answer of a single register. Fields the register does not know stay empty.
+type Result struct {
+	Source string `json:"source"`
+	VATNo  string `json:"vat_no"`
+	// Valid reports whether the number is registered for VAT.
+	Valid   *bool  `json:"valid,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address,omitempty"`
+	// Unreliable is set by registers of unreliable VAT payers (Czech "nespolehlivý plátce").
+	Unreliable      *bool  `json:"unreliable,omitempty"`
+	UnreliableSince string `json:"unreliable_since,omitempty"`
+	// BankAccounts lists the published bank accounts, when the register keeps them.
+	BankAccounts []string `json:"bank_accounts,omitempty"`
+}
+
+// Checker validates a VAT number in one register.
+type Checker interface {
+	Check(ctx context.Context, vatNo string) (*Result, error)
+}
+
+// Report combines the results of several registers.
+type Report struct {
+	VATNo       string   `json:"vat_no"`
+	BankAccount string   `json:"bank_account,omitempty"`
+	Results     []Result `json:"results"`
+	// Problems are findings that should stop an invoice or a payment.
+	Problems []string `json:"problems,omitempty"`
+	// Errors are registers that could not be queried.
+	Errors []string `json:"errors,omitempty"`
+	// Verified is true when at least one register answered and none failed.
+	Verified bool `json:"verified"`
+}
+
+// OK reports whether the checks found no problems.
+func (r Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Run queries all checkers that cover the VAT number. When bankAccount is set, it is
+// compared with the accounts published by the registers.
+func Run(ctx context.Context, checkers []Checker, vatNo, bankAccount string) Report {
+	vatNo = Normalize(vatNo)
+	report := Report{VATNo: vatNo, BankAccount: bankAccount}
+	for _, c := range checkers {
+		res, err := c.Check(ctx, vatNo)
+		if errors.Is(err, ErrNotApplicable) {
+			continue
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, err.Error())
+			continue
+		}
+		report.Results = append(report.Results, *res)
+
+		if res.Valid != nil && !*res.Valid {
+			report.Problems = append(report.Problems, fmt.Sprintf("%s is not a valid VAT number (%s)", vatNo, res.Source))
+		}
+		if res.Unreliable != nil && *res.Unreliable {
+			msg := fmt.Sprintf("%s is an unreliable VAT payer (%s)", vatNo, res.Source)
+			if res.UnreliableSince != "" {
+				msg = fmt.Sprintf("%s is an unreliable VAT payer since %s (%s)", vatNo, res.UnreliableSince, res.Source)
+			}
+			report.Problems = append(report.Problems, msg)
+		}
+		if bankAccount != "" && res.BankAccounts != nil && !hasAccount(res.BankAccounts, bankAccount) {
+			report.Problems = append(report.Problems, fmt.Sprintf("bank account %s is not published for %s (%s)", bankAccount, vatNo, res.Source))
+		}
+	}
+	if len(report.Results) == 0 && len(report.Errors) == 0 {
+		report.Errors = append(report.Errors, fmt.Sprintf("no register covers %s", vatNo))
+	}
+	report.Verified = len(report.Results) > 0 && len(report.Errors) == 0
+	return report
+}
+
+// Normalize uppercases a VAT number and strips spaces and dots.
+func Normalize(vatNo string) string {
+	return strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(strings.TrimSpace(vatNo)))
+}
+
+// Split separates the country prefix from the number. Greek numbers use "EL".
+func Split(vatNo string) (country, number string, err error) {
+	vatNo = Normalize(vatNo)
+	if len(vatNo) < 4 || vatNo[0] < 'A' || vatNo[0] > 'Z' || vatNo[1] < 'A' || vatNo[1] > 'Z' {
+		return "", "", fmt.Errorf("VAT number %q must start with a country code", vatNo)
+	}
+	return vatNo[:2], vatNo[2:], nil
+}
+
+func hasAccount(accounts []string, account string) bool {
+	want := NormalizeAccount(account)
+	for _, a := range accounts {
+		if NormalizeAccount(a) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeAccount brings a Czech account number ("19-2000145399/0800") or IBAN to a
+// comparable form. Czech IBANs are converted to the domestic format.
+func NormalizeAccount(account string) string {
+	account = strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(account), " ", ""))
+	if len(account) == 24 && strings.HasPrefix(account, "CZ") {
+		bank, prefix, number := account[4:8], account[8:14], account[14:]
+		account = prefix + "-" + number + "/" + bank
+	}
+	number, bank, ok := strings.Cut(account, "/")
+	if !ok {
+		return account
+	}
+	prefix, number, ok := strings.Cut(number, "-")
+	if !ok {
+		prefix, number = "", prefix
+	}
+	prefix = strings.TrimLeft(prefix, "0")
+	number = strings.TrimLeft(number, "0")
+	if prefix != "" {
+		number = prefix + "-" + number
+	}
+	return number + "/" + bank
+}
+
+// Static is an in-memory checker keyed by normalized VAT number, useful as a local fake.
+// Numbers it does not know are reported as not applicable.
+type Static map[string]Result
+
+func (s Static) Check(ctx context.Context, vatNo string) (*Result, error) {
+	res, ok := s[Normalize(vatNo)]
+	if !ok {
+		return nil, ErrNotApplicable
+	}
+	return &res, nil
+}
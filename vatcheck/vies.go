@@ -0,0 +1,103 @@
+package vatcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultVIESURL is the VIES REST API of the European Commission.
+const DefaultVIESURL = "https://ec.europa.eu/taxation_customs/vies/rest-api"
+
+// viesCountries are the country prefixes VIES answers for.
+var viesCountries = map[string]bool{
+	"AT": true, "BE": true, "BG": true, "CY": true, "CZ": true, "DE": true, "DK": true,
+	"EE": true, "EL": true, "ES": true, "FI": true, "FR": true, "HR": true, "HU": true,
+	"IE": true, "IT": true, "LT": true, "LU": true, "LV": true, "MT": true, "NL": true,
+	"PL": true, "PT": true, "RO": true, "SE": true, "SI": true, "SK": true, "XI": true,
+}
+
+// VIES checks EU VAT numbers in the VAT Information Exchange System.
+type VIES struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+func NewVIES() *VIES {
+	return &VIES{
+		BaseURL:    DefaultVIESURL,
+		HTTPClient: &http.Client{Timeout: 20 * time.Second},
+	}
+}
+
+type viesResponse struct {
+	Valid     bool   `json:"isValid"`
+	UserError string `json:"userError"`
+	Name      string `json:"name"`
+	Address   string `json:"address"`
+}
+
+func (v *VIES) Check(ctx context.Context, vatNo string) (*Result, error) {
+	country, number, err := Split(vatNo)
+	if err != nil {
+		return nil, err
+	}
+	if country == "GR" {
+		country = "EL"
+	}
+	if !viesCountries[country] {
+		return nil, ErrNotApplicable
+	}
+
+	url := fmt.Sprintf("%s/ms/%s/vat/%s", strings.TrimRight(v.BaseURL, "/"), country, number)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create VIES request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("VIES request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read VIES response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("VIES error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var r viesResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("unmarshal VIES response: %w", err)
+	}
+	// userError is VALID or INVALID for a real answer; anything else (MS_UNAVAILABLE,
+	// TIMEOUT, ...) means the member state could not be asked.
+	if r.UserError != "" && r.UserError != "VALID" && r.UserError != "INVALID" {
+		return nil, fmt.Errorf("VIES could not check %s: %s", vatNo, r.UserError)
+	}
+
+	res := &Result{
+		Source:  "VIES",
+		VATNo:   Normalize(vatNo),
+		Valid:   &r.Valid,
+		Name:    viesText(r.Name),
+		Address: viesText(r.Address),
+	}
+	return res, nil
+}
+
+// viesText cleans up names and addresses; "---" means the member state does not share them.
+func viesText(s string) string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\n", ", "))
+	if s == "---" {
+		return ""
+	}
+	return s
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+// maxBodySize limits the size of a single webhook delivery.
+const maxBodySize = 1 << 20
+
+// Receiver is an http.Handler accepting Fakturoid webhook deliveries.
+//
+// Fakturoid sends the webhook's configured auth_header value in the Authorization
+// header; deliveries without the matching secret are rejected.
+type Receiver struct {
+	store   *Store
+	secret  string
+	onEvent func(Event)
+}
+
+// NewReceiver creates a receiver. onEvent, if not nil, is called after each stored event.
+func NewReceiver(store *Store, secret string, onEvent func(Event)) *Receiver {
+	return &Receiver{store: store, secret: secret, onEvent: onEvent}
+}
+
+type delivery struct {
+	WebhookID int             `json:"webhook_id"`
+	EventName string          `json:"event_name"`
+	CreatedAt string          `json:"created_at"`
+	Body      json.RawMessage `json:"body"`
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !rc.authorized(req) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "read body", http.StatusBadRequest)
+		return
+	}
+	var d delivery
+	if err := json.Unmarshal(data, &d); err != nil || d.EventName == "" {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	e := Event{
+		ReceivedAt: time.Now(),
+		WebhookID:  d.WebhookID,
+		EventName:  d.EventName,
+		CreatedAt:  d.CreatedAt,
+		Body:       d.Body,
+	}
+	if err := rc.store.Add(e); err != nil {
+		log.Printf("webhook: %v", err)
+	}
+	if rc.onEvent != nil {
+		rc.onEvent(e)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (rc *Receiver) authorized(req *http.Request) bool {
+	got := req.Header.Get("Authorization")
+	return subtle.ConstantTimeCompare([]byte(got), []byte(rc.secret)) == 1
+}
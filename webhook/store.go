@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxEvents is how many events the store keeps in memory.
+const maxEvents = 1000
+
+// Event is a webhook delivery received from Fakturoid.
+type Event struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	WebhookID  int             `json:"webhook_id"`
+	EventName  string          `json:"event_name"`
+	CreatedAt  string          `json:"created_at"`
+	Body       json.RawMessage `json:"body,omitempty"`
+}
+
+// Store keeps recently received events, optionally appending them to a JSON-lines file.
+type Store struct {
+	mu     sync.Mutex
+	events []Event
+	path   string
+}
+
+// NewStore creates a store. If path is not empty, events already in the file are loaded
+// and new events are appended to it.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path}
+	if path == "" {
+		return s, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open webhook store: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		s.events = append(s.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read webhook store: %w", err)
+	}
+	s.trim()
+	return s, nil
+}
+
+func (s *Store) Add(e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, e)
+	s.trim()
+
+	if s.path == "" {
+		return nil
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("marshal webhook event: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open webhook store: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write webhook store: %w", err)
+	}
+	return nil
+}
+
+// List returns events newest first. Empty name matches all events, zero since matches any time,
+// and limit <= 0 returns everything.
+func (s *Store) List(name string, since time.Time, limit int) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := []Event{}
+	for i := len(s.events) - 1; i >= 0; i-- {
+		e := s.events[i]
+		if name != "" && e.EventName != name {
+			continue
+		}
+		if !since.IsZero() && e.ReceivedAt.Before(since) {
+			continue
+		}
+		result = append(result, e)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result
+}
+
+func (s *Store) trim() {
+	if len(s.events) > maxEvents {
+		s.events = append([]Event(nil), s.events[len(s.events)-maxEvents:]...)
+	}
+}